@@ -0,0 +1,88 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// HeadStyle is the shape of an arrowhead.
+type HeadStyle int
+
+const (
+	// NoHead draws no arrowhead.
+	NoHead HeadStyle = iota
+	// TriangleHead is a filled triangle.
+	TriangleHead
+	// OpenHead is an open V, stroked at the line width.
+	OpenHead
+)
+
+// ArrowEnds selects which ends of a line receive arrowheads.
+type ArrowEnds int
+
+const (
+	// HeadEnd places an arrowhead at the last point.
+	HeadEnd ArrowEnds = 1 << iota
+	// HeadStart places an arrowhead at the first point.
+	HeadStart
+	// HeadBoth places arrowheads at both ends.
+	HeadBoth = HeadStart | HeadEnd
+)
+
+// Arrow draws a line from (x1,y1) to (x2,y2) with an arrowhead at (x2,y2),
+// with specified stroke color and width. headSize is the length of the head.
+func (p *PDFDoc) Arrow(x1, y1, x2, y2, sw float64, color string, headStyle HeadStyle, headSize float64) {
+	p.ArrowPolyline([]float64{x1, x2}, []float64{y1, y2}, sw, color, headStyle, headSize, HeadEnd)
+}
+
+// ArrowPolyline draws a polyline with arrowheads at the selected ends
+func (p *PDFDoc) ArrowPolyline(x []float64, y []float64, sw float64, color string, headStyle HeadStyle, headSize float64, ends ArrowEnds) {
+	n := len(x)
+	if n != len(y) || n < 2 {
+		return
+	}
+	lx := append([]float64(nil), x...)
+	ly := append([]float64(nil), y...)
+	if ends&HeadStart != 0 {
+		lx[0], ly[0] = p.arrowhead(x[1], y[1], x[0], y[0], sw, color, headStyle, headSize)
+	}
+	if ends&HeadEnd != 0 {
+		lx[n-1], ly[n-1] = p.arrowhead(x[n-2], y[n-2], x[n-1], y[n-1], sw, color, headStyle, headSize)
+	}
+	p.Polyline(lx, ly, sw, color)
+}
+
+// ArrowCurve draws a quadratic Bezier curve (as Curve) with arrowheads at the selected ends
+func (p *PDFDoc) ArrowCurve(x1, y1, x2, y2, x3, y3, sw float64, color string, headStyle HeadStyle, headSize float64, ends ArrowEnds) {
+	sx, sy, ex, ey := x1, y1, x3, y3
+	if ends&HeadStart != 0 {
+		sx, sy = p.arrowhead(x2, y2, x1, y1, sw, color, headStyle, headSize)
+	}
+	if ends&HeadEnd != 0 {
+		ex, ey = p.arrowhead(x2, y2, x3, y3, sw, color, headStyle, headSize)
+	}
+	p.Curve(sx, sy, x2, y2, ex, ey, sw, color)
+}
+
+// arrowhead draws a head with its tip at (tx,ty), pointing away from (fx,fy).
+// It returns the point where the line should end so that it does not show past the tip.
+func (p *PDFDoc) arrowhead(fx, fy, tx, ty, sw float64, color string, style HeadStyle, size float64) (float64, float64) {
+	dx, dy := tx-fx, ty-fy
+	d := math.Hypot(dx, dy)
+	if d == 0 || style == NoHead || size <= 0 {
+		return tx, ty
+	}
+	ux, uy := dx/d, dy/d
+	bx, by := tx-size*ux, ty-size*uy
+	hw := size / 2
+	lx, ly := bx-hw*uy, by+hw*ux
+	rx, ry := bx+hw*uy, by-hw*ux
+	switch style {
+	case TriangleHead:
+		p.Polygon([]float64{tx, lx, rx}, []float64{ty, ly, ry}, color)
+		return tx - (size/2)*ux, ty - (size/2)*uy
+	case OpenHead:
+		fmt.Fprintf(p.Writer, "%.2f w %s %.2f %.2f m %.2f %.2f l %.2f %.2f l S\n", sw, p.strokeop(color), lx, ly, tx, ty, rx, ry)
+	}
+	return tx, ty
+}
@@ -0,0 +1,184 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// Axis describes a numeric axis, drawn by XAxis and YAxis.
+type Axis struct {
+	Min, Max    float64 // data range, mapped to the length of the axis
+	Step        float64 // interval between ticks; a "nice" interval if zero
+	Format      string  // format of the tick labels, for example "%.1f"; if empty, as precise as the step
+	TickSize    float64 // length of the tick marks, default 4
+	Font        string  // font of the labels, default sans
+	FontSize    float64 // size of the labels, default 8
+	Color       string  // color of the axis, ticks, and labels, default the theme foreground
+	StrokeWidth float64 // width of the axis and ticks, default 0.5
+	GridColor   string  // color of gridlines at the ticks; none if empty
+	GridLength  float64 // length of the gridlines, across the plot
+}
+
+// defaults fills in the default values
+func (a Axis) defaults() Axis {
+	if a.Step <= 0 {
+		_, _, a.Step = NiceTicks(a.Min, a.Max, 6)
+	}
+	if a.TickSize == 0 {
+		a.TickSize = 4
+	}
+	if a.Font == "" {
+		a.Font = "sans"
+	}
+	if a.FontSize == 0 {
+		a.FontSize = 8
+	}
+	if a.Color == "" {
+		a.Color = "foreground"
+	}
+	if a.StrokeWidth == 0 {
+		a.StrokeWidth = 0.5
+	}
+	return a
+}
+
+// Label formats a tick value
+func (a Axis) Label(v float64) string {
+	if a.Format != "" {
+		return fmt.Sprintf(a.Format, v)
+	}
+	return fmt.Sprintf("%.*f", stepdecimals(a.Step), v)
+}
+
+// stepdecimals returns the number of decimal places needed to show multiples of step
+func stepdecimals(step float64) int {
+	if step <= 0 || step >= 1 {
+		return 0
+	}
+	return int(math.Ceil(-math.Log10(step) - 1e-9))
+}
+
+// XAxis draws a horizontal axis from (x,y) to (x+length,y), with ticks and labels below it.
+// Gridlines, if any, extend upward.
+func (p *PDFDoc) XAxis(x, y, length float64, a Axis) {
+	a = a.defaults()
+	ticks := Ticks(a.Min, a.Max, a.Step)
+	pos := func(v float64) float64 { return x + MapRange(v, a.Min, a.Max, 0, length) }
+	if visible(a.GridColor) {
+		for _, v := range ticks {
+			p.MoveTo(pos(v), y)
+			p.LineTo(pos(v), y+a.GridLength)
+		}
+		p.StrokePath(a.StrokeWidth/2, a.GridColor)
+	}
+	p.MoveTo(x, y)
+	p.LineTo(x+length, y)
+	for _, v := range ticks {
+		p.MoveTo(pos(v), y)
+		p.LineTo(pos(v), y-a.TickSize)
+	}
+	p.StrokePath(a.StrokeWidth, a.Color)
+	for _, v := range ticks {
+		l := a.Label(v)
+		p.Text(pos(v)-TextWidth(l, a.Font, a.FontSize)/2, y-a.TickSize-a.FontSize, l, a.Font, a.FontSize, a.Color)
+	}
+}
+
+// YAxis draws a vertical axis from (x,y) to (x,y+length), with ticks and labels to the left of it.
+// Gridlines, if any, extend to the right.
+func (p *PDFDoc) YAxis(x, y, length float64, a Axis) {
+	a = a.defaults()
+	ticks := Ticks(a.Min, a.Max, a.Step)
+	pos := func(v float64) float64 { return y + MapRange(v, a.Min, a.Max, 0, length) }
+	if visible(a.GridColor) {
+		for _, v := range ticks {
+			p.MoveTo(x, pos(v))
+			p.LineTo(x+a.GridLength, pos(v))
+		}
+		p.StrokePath(a.StrokeWidth/2, a.GridColor)
+	}
+	p.MoveTo(x, y)
+	p.LineTo(x, y+length)
+	for _, v := range ticks {
+		p.MoveTo(x, pos(v))
+		p.LineTo(x-a.TickSize, pos(v))
+	}
+	p.StrokePath(a.StrokeWidth, a.Color)
+	for _, v := range ticks {
+		l := a.Label(v)
+		p.Text(x-a.TickSize-2-TextWidth(l, a.Font, a.FontSize), pos(v)-a.FontSize/3, l, a.Font, a.FontSize, a.Color)
+	}
+}
+
+// MapRange maps v from the range [min,max] to [lo,hi]
+func MapRange(v, min, max, lo, hi float64) float64 {
+	if max == min {
+		return lo
+	}
+	return lo + (v-min)/(max-min)*(hi-lo)
+}
+
+// Ticks returns the multiples of step within [min,max]
+func Ticks(min, max, step float64) []float64 {
+	if step <= 0 || max < min {
+		return nil
+	}
+	var ticks []float64
+	first := math.Ceil(min/step - 1e-9)
+	for i := first; i*step <= max+step*1e-9; i++ {
+		v := i * step
+		if v == 0 {
+			v = 0 // avoid -0
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
+// NiceTicks returns a range covering [min,max] and a tick step, both of round numbers
+// (1, 2, or 5 times a power of ten), giving about n ticks
+func NiceTicks(min, max float64, n int) (lo, hi, step float64) {
+	if n < 2 {
+		n = 2
+	}
+	if max < min {
+		min, max = max, min
+	}
+	if max == min {
+		max = min + 1
+	}
+	span := nicenum(max-min, false)
+	step = nicenum(span/float64(n-1), true)
+	return math.Floor(min/step) * step, math.Ceil(max/step) * step, step
+}
+
+// nicenum returns a round number near x; rounded to the nearest, or else the next larger
+func nicenum(x float64, round bool) float64 {
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+	var nf float64
+	if round {
+		switch {
+		case f < 1.5:
+			nf = 1
+		case f < 3:
+			nf = 2
+		case f < 7:
+			nf = 5
+		default:
+			nf = 10
+		}
+	} else {
+		switch {
+		case f <= 1:
+			nf = 1
+		case f <= 2:
+			nf = 2
+		case f <= 5:
+			nf = 5
+		default:
+			nf = 10
+		}
+	}
+	return nf * math.Pow(10, exp)
+}
@@ -0,0 +1,131 @@
+// Package barcode draws linear barcodes on pdfgen documents: Code 128, EAN-13, and Code 39.
+package barcode
+
+import (
+	"errors"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// ErrInvalid is returned for data that cannot be encoded in the symbology.
+var ErrInvalid = errors.New("barcode: invalid data")
+
+// Barcode is an encoded barcode, ready to draw.
+type Barcode struct {
+	Text    string // the human-readable text, including any check digit shown
+	modules []bool // the bars (true) and spaces, one module each
+	guards  []bool // modules of EAN guard bars, which extend into the text
+	quiet   [2]int // modules of the quiet zones, before and after the bars
+	ean     bool   // set the text in the EAN manner, under the halves
+}
+
+// Options describes how a barcode is drawn.
+type Options struct {
+	Module   float64 // width of a module (the narrowest bar), default 1
+	Height   float64 // height of the bars, default 50
+	Text     bool    // show the human-readable text beneath the bars
+	Font     string  // font of the text, default mono
+	FontSize float64 // size of the text, default 10 times the module
+	Color    string  // color of the bars and text, default black
+}
+
+// defaults fills in the default values
+func (o Options) defaults() Options {
+	if o.Module == 0 {
+		o.Module = 1
+	}
+	if o.Height == 0 {
+		o.Height = 50
+	}
+	if o.Font == "" {
+		o.Font = "mono"
+	}
+	if o.FontSize == 0 {
+		o.FontSize = 10 * o.Module
+	}
+	if o.Color == "" {
+		o.Color = "black"
+	}
+	return o
+}
+
+// Width returns the width of the barcode, including its quiet zones, with modules of width module
+func (b Barcode) Width(module float64) float64 {
+	return float64(b.quiet[0]+len(b.modules)+b.quiet[1]) * module
+}
+
+// Height returns the height of the barcode drawn with the options, including its text
+func (b Barcode) Height(opts Options) float64 {
+	opts = opts.defaults()
+	if opts.Text {
+		return opts.Height + opts.FontSize*1.1
+	}
+	return opts.Height
+}
+
+// Draw draws the barcode with the lower left of its quiet zone at (x,y).
+// The bars are drawn in the foreground color only; the spaces and quiet zones are left unpainted,
+// so the barcode should be placed on a light background.
+func (b Barcode) Draw(doc *pdfgen.PDFDoc, x, y float64, opts Options) {
+	opts = opts.defaults()
+	m := opts.Module
+	text := 0.0
+	if opts.Text {
+		text = opts.FontSize * 1.1
+	}
+	bx := x + float64(b.quiet[0])*m
+	for i := 0; i < len(b.modules); {
+		if !b.modules[i] {
+			i++
+			continue
+		}
+		j := i
+		guard := i < len(b.guards) && b.guards[i]
+		for j < len(b.modules) && b.modules[j] && (j < len(b.guards) && b.guards[j]) == guard {
+			j++
+		}
+		by, bh := y+text, opts.Height
+		if guard && opts.Text {
+			by, bh = y+text/2, opts.Height+text/2
+		}
+		doc.Rect(bx+float64(i)*m, by, float64(j-i)*m, bh, opts.Color)
+		i = j
+	}
+	if !opts.Text {
+		return
+	}
+	ty := y + text - opts.FontSize
+	if b.ean && len(b.Text) == 13 {
+		// the first digit in the quiet zone, then six under each half, between the guards
+		b.text(doc, b.Text[:1], x+float64(b.quiet[0])*m-opts.FontSize, ty, opts.FontSize, opts)
+		b.text(doc, b.Text[1:7], bx+3*m, ty, 42*m, opts)
+		b.text(doc, b.Text[7:], bx+50*m, ty, 42*m, opts)
+		return
+	}
+	b.text(doc, b.Text, bx, ty, float64(len(b.modules))*m, opts)
+}
+
+// text draws s centered in the width from x
+func (b Barcode) text(doc *pdfgen.PDFDoc, s string, x, y, width float64, opts Options) {
+	w := pdfgen.TextWidth(s, opts.Font, opts.FontSize)
+	doc.Text(x+(width-w)/2, y, s, opts.Font, opts.FontSize, opts.Color)
+}
+
+// widths appends the modules of alternating bars and spaces, beginning with a bar,
+// of the widths given as digits
+func widths(modules []bool, pattern string) []bool {
+	for i := 0; i < len(pattern); i++ {
+		for n := int(pattern[i] - '0'); n > 0; n-- {
+			modules = append(modules, i%2 == 0)
+		}
+	}
+	return modules
+}
+
+// bits appends the modules given as 1 (bar) and 0 (space)
+func bits(modules []bool, pattern string) []bool {
+	for i := 0; i < len(pattern); i++ {
+		modules = append(modules, pattern[i] == '1')
+	}
+	return modules
+}
@@ -0,0 +1,92 @@
+package barcode
+
+import "testing"
+
+func TestEANCheck(t *testing.T) {
+	tests := []struct {
+		digits string
+		check  byte
+		err    error
+	}{
+		{"400638133393", '1', nil},
+		{"978020137962", '4', nil},
+		{"590123412345", '7', nil},
+		{"000000000000", '0', nil},
+		{"5901234123457", '7', nil}, // the check digit given is not read
+		{"40063813339", 0, ErrInvalid},
+		{"40063813339x", 0, ErrInvalid},
+	}
+	for _, tt := range tests {
+		check, err := EANCheck(tt.digits)
+		if check != tt.check || err != tt.err {
+			t.Errorf("EANCheck(%q) = %q, %v; want %q, %v", tt.digits, check, err, tt.check, tt.err)
+		}
+	}
+}
+
+// symbols decodes the modules of a Code 128 barcode into its symbol values
+func symbols(t *testing.T, modules []bool) []int {
+	values := make(map[string]int, len(code128))
+	for v, w := range code128 {
+		values[w] = v
+	}
+	var out []int
+	for i := 0; i < len(modules); {
+		n := 11
+		if len(modules)-i == 13 {
+			n = 13 // the stop symbol, with its final bar
+		}
+		var w []byte
+		for j := i; j < i+n; {
+			k := j
+			for k < i+n && modules[k] == modules[j] {
+				k++
+			}
+			w = append(w, byte('0'+k-j))
+			j = k
+		}
+		v, ok := values[string(w)]
+		if !ok {
+			t.Fatalf("no symbol has the widths %s", w)
+		}
+		out = append(out, v)
+		i += n
+	}
+	return out
+}
+
+func TestCode128(t *testing.T) {
+	tests := []struct {
+		text   string
+		values []int // start, data, check, and stop
+	}{
+		{"PJJ123C", []int{startB, 48, 42, 42, 17, 18, 19, 35, 55, stop}},
+		{"1234", []int{startC, 12, 34, 82, stop}},
+		{"12345", []int{startC, 12, 34, codeB, 21, 54, stop}},
+		{"AB12345678", []int{startB, 33, 34, codeC, 12, 34, 56, 78, 57, stop}},
+		{"123456a", []int{startC, 12, 34, 56, codeB, 65, 48, stop}},
+	}
+	for _, tt := range tests {
+		b, err := Code128(tt.text)
+		if err != nil {
+			t.Errorf("Code128(%q): %v", tt.text, err)
+			continue
+		}
+		got := symbols(t, b.modules)
+		if len(got) != len(tt.values) {
+			t.Errorf("Code128(%q) = %v; want %v", tt.text, got, tt.values)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.values[i] {
+				t.Errorf("Code128(%q) = %v; want %v", tt.text, got, tt.values)
+				break
+			}
+		}
+	}
+	for _, s := range []string{"", "tab\there", "caf\xe9"} {
+		if _, err := Code128(s); err != ErrInvalid {
+			t.Errorf("Code128(%q) error = %v; want ErrInvalid", s, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package barcode
+
+// Code 128 symbols: the bar and space widths of each value, 0 to 106
+var code128 = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// Code 128 control values
+const (
+	codeC  = 99
+	codeB  = 100
+	startB = 104
+	startC = 105
+	stop   = 106
+)
+
+// digitrun returns the number of digits in s from i
+func digitrun(s string, i int) int {
+	n := 0
+	for i+n < len(s) && s[i+n] >= '0' && s[i+n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// Code128 encodes printable ASCII text in Code 128, using code set C for runs of digits
+// (which it packs two to a symbol) and code set B for the rest
+func Code128(s string) (Barcode, error) {
+	if s == "" {
+		return Barcode{}, ErrInvalid
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 32 || s[i] > 126 {
+			return Barcode{}, ErrInvalid
+		}
+	}
+	var values []int
+	inC := false
+	for i := 0; i < len(s); {
+		run := digitrun(s, i)
+		// code set C pays for its switch with runs of four at the ends, or six within
+		wantC := run >= 4 && (i == 0 || i+run == len(s)) || run >= 6
+		switch {
+		case wantC && !inC:
+			run -= run % 2
+			if len(values) == 0 {
+				values = append(values, startC)
+			} else {
+				values = append(values, codeC)
+			}
+			inC = true
+		case inC && run < 2:
+			values = append(values, codeB)
+			inC = false
+		case len(values) == 0:
+			values = append(values, startB)
+		}
+		if inC {
+			for ; run >= 2; run -= 2 {
+				values = append(values, int(s[i]-'0')*10+int(s[i+1]-'0'))
+				i += 2
+			}
+			continue
+		}
+		values = append(values, int(s[i])-32)
+		i++
+	}
+	check := values[0]
+	for i, v := range values[1:] {
+		check += (i + 1) * v
+	}
+	values = append(values, check%103, stop)
+	var modules []bool
+	for _, v := range values {
+		modules = widths(modules, code128[v])
+	}
+	return Barcode{Text: s, modules: modules, quiet: [2]int{10, 10}}, nil
+}
@@ -0,0 +1,57 @@
+package barcode
+
+import "strings"
+
+// code39chars are the Code 39 characters, in order of their check values
+const code39chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+
+// Code 39 characters: the narrow (n) and wide (w) bars and spaces, alternating from a bar
+var code39 = map[byte]string{
+	'0': "nnnwwnwnn", '1': "wnnwnnnnw", '2': "nnwwnnnnw", '3': "wnwwnnnnn", '4': "nnnwwnnnw",
+	'5': "wnnwwnnnn", '6': "nnwwwnnnn", '7': "nnnwnnwnw", '8': "wnnwnnwnn", '9': "nnwwnnwnn",
+	'A': "wnnnnwnnw", 'B': "nnwnnwnnw", 'C': "wnwnnwnnn", 'D': "nnnnwwnnw", 'E': "wnnnwwnnn",
+	'F': "nnwnwwnnn", 'G': "nnnnnwwnw", 'H': "wnnnnwwnn", 'I': "nnwnnwwnn", 'J': "nnnnwwwnn",
+	'K': "wnnnnnnww", 'L': "nnwnnnnww", 'M': "wnwnnnnwn", 'N': "nnnnwnnww", 'O': "wnnnwnnwn",
+	'P': "nnwnwnnwn", 'Q': "nnnnnnwww", 'R': "wnnnnnwwn", 'S': "nnwnnnwwn", 'T': "nnnnwnwwn",
+	'U': "wwnnnnnnw", 'V': "nwwnnnnnw", 'W': "wwwnnnnnn", 'X': "nwnnwnnnw", 'Y': "wwnnwnnnn",
+	'Z': "nwwnwnnnn", '-': "nwnnnnwnw", '.': "wwnnnnwnn", ' ': "nwwnnnwnn", '$': "nwnwnwnnn",
+	'/': "nwnwnnnwn", '+': "nwnnnwnwn", '%': "nnnwnwnwn", '*': "nwnnwnwnn",
+}
+
+// code39wide is the width of a wide element, in modules
+const code39wide = 3
+
+// Code39 encodes text of digits, upper case letters, and "-. $/+%" in Code 39, between
+// the * start and stop characters, optionally with a modulo 43 check character
+func Code39(s string, check bool) (Barcode, error) {
+	if s == "" {
+		return Barcode{}, ErrInvalid
+	}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(code39chars, s[i])
+		if v < 0 {
+			return Barcode{}, ErrInvalid
+		}
+		sum += v
+	}
+	if check {
+		s += string(code39chars[sum%43])
+	}
+	var modules []bool
+	for i, c := range []byte("*" + s + "*") {
+		if i > 0 {
+			modules = append(modules, false) // the narrow gap between characters
+		}
+		for j, e := range code39[c] {
+			n := 1
+			if e == 'w' {
+				n = code39wide
+			}
+			for ; n > 0; n-- {
+				modules = append(modules, j%2 == 0)
+			}
+		}
+	}
+	return Barcode{Text: s, modules: modules, quiet: [2]int{10, 10}}, nil
+}
@@ -0,0 +1,76 @@
+package barcode
+
+// EAN digit codes: left-hand odd (L) and even (G) parity, and right-hand (R)
+var (
+	eanL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	eanR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+)
+
+// eanparity is the parity of the left-hand digits, set by the first digit
+var eanparity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+
+// EANCheck returns the check digit of the first twelve digits of an EAN-13 number
+func EANCheck(digits string) (byte, error) {
+	if len(digits) < 12 {
+		return 0, ErrInvalid
+	}
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := digits[i]
+		if d < '0' || d > '9' {
+			return 0, ErrInvalid
+		}
+		if i%2 == 0 {
+			sum += int(d - '0')
+		} else {
+			sum += 3 * int(d-'0')
+		}
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// EAN13 encodes an EAN-13 number: twelve digits, to which the check digit is added,
+// or thirteen, whose check digit is verified
+func EAN13(digits string) (Barcode, error) {
+	if len(digits) != 12 && len(digits) != 13 {
+		return Barcode{}, ErrInvalid
+	}
+	check, err := EANCheck(digits)
+	if err != nil {
+		return Barcode{}, err
+	}
+	if len(digits) == 13 && digits[12] != check {
+		return Barcode{}, ErrInvalid
+	}
+	digits = digits[:12] + string(check)
+
+	var modules, guards []bool
+	guard := func(pattern string) {
+		modules = bits(modules, pattern)
+		for len(guards) < len(modules) {
+			guards = append(guards, true)
+		}
+	}
+	digit := func(pattern string) {
+		modules = bits(modules, pattern)
+		for len(guards) < len(modules) {
+			guards = append(guards, false)
+		}
+	}
+	parity := eanparity[digits[0]-'0']
+	guard("101")
+	for i := 1; i <= 6; i++ {
+		if parity[i-1] == 'G' {
+			digit(eanG[digits[i]-'0'])
+		} else {
+			digit(eanL[digits[i]-'0'])
+		}
+	}
+	guard("01010")
+	for i := 7; i <= 12; i++ {
+		digit(eanR[digits[i]-'0'])
+	}
+	guard("101")
+	return Barcode{Text: digits, modules: modules, guards: guards, quiet: [2]int{11, 7}, ean: true}, nil
+}
@@ -0,0 +1,59 @@
+package pdfgen
+
+import "math"
+
+// Braces and brackets span from (x1,y1) to (x2,y2), and point away from the span,
+// to the left as seen traveling from (x1,y1) to (x2,y2), by depth units.
+// For example, a brace from right to left points down; from bottom to top, it points left.
+
+// Brace draws a curly brace with specified stroke color and width
+func (p *PDFDoc) Brace(x1, y1, x2, y2, depth, sw float64, color string) {
+	l := math.Hypot(x2-x1, y2-y1)
+	if l == 0 {
+		return
+	}
+	at := spanframe(x1, y1, x2, y2)
+	d := depth
+	r := math.Min(d/2, l/4)
+	p.MoveTo(at(0, 0))
+	p.quadto(at, 0, d/2, r, d/2)
+	p.LineTo(at(l/2-r, d/2))
+	p.quadto(at, l/2, d/2, l/2, d)
+	p.quadto(at, l/2, d/2, l/2+r, d/2)
+	p.LineTo(at(l-r, d/2))
+	p.quadto(at, l, d/2, l, 0)
+	p.StrokePath(sw, color)
+}
+
+// Bracket draws a square bracket, with a tick at its middle, with specified stroke color and width
+func (p *PDFDoc) Bracket(x1, y1, x2, y2, depth, sw float64, color string) {
+	l := math.Hypot(x2-x1, y2-y1)
+	if l == 0 {
+		return
+	}
+	at := spanframe(x1, y1, x2, y2)
+	p.MoveTo(at(0, 0))
+	p.LineTo(at(0, depth/2))
+	p.LineTo(at(l, depth/2))
+	p.LineTo(at(l, 0))
+	p.MoveTo(at(l/2, depth/2))
+	p.LineTo(at(l/2, depth))
+	p.StrokePath(sw, color)
+}
+
+// spanframe returns a function mapping (u,v) coordinates, where u is the distance along
+// the span from (x1,y1) toward (x2,y2), and v the distance to its left, to page coordinates
+func spanframe(x1, y1, x2, y2 float64) func(u, v float64) (float64, float64) {
+	l := math.Hypot(x2-x1, y2-y1)
+	tx, ty := (x2-x1)/l, (y2-y1)/l
+	return func(u, v float64) (float64, float64) {
+		return x1 + u*tx - v*ty, y1 + u*ty + v*tx
+	}
+}
+
+// quadto appends a quadratic curve given in the (u,v) coordinates of the frame at
+func (p *PDFDoc) quadto(at func(u, v float64) (float64, float64), cu, cv, u, v float64) {
+	cx, cy := at(cu, cv)
+	x, y := at(u, v)
+	p.QuadTo(cx, cy, x, y)
+}
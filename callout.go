@@ -0,0 +1,70 @@
+package pdfgen
+
+import "math"
+
+// Callout draws a rounded speech bubble containing s wrapped to maxwidth, with its upper left at (x,y),
+// and a tail pointing to the target (tx,ty). The bubble is sized to fit the wrapped text.
+// No tail is drawn if the target is within the bubble.
+func (p *PDFDoc) Callout(x, y, maxwidth, tx, ty float64, s, font string, size float64, textcolor, fillcolor string) {
+	pad := size * 0.6
+	leading := size * 1.2
+	lines := WrapText(s, font, size, maxwidth-2*pad)
+	tw := 0.0
+	for _, l := range lines {
+		tw = math.Max(tw, TextWidth(l, font, size))
+	}
+	w := tw + 2*pad
+	h := float64(len(lines)-1)*leading + size + 2*pad
+	bottom := y - h
+	r := pad
+
+	p.roundrectpath(x, bottom, w, h, r)
+	p.FillPath(fillcolor)
+	p.callouttail(x, bottom, w, h, r, tx, ty, fillcolor)
+	for i, l := range lines {
+		p.Text(x+pad, y-pad-size*0.8-float64(i)*leading, l, font, size, textcolor)
+	}
+}
+
+// callouttail draws a triangular tail from the nearest side of the box to (tx,ty)
+func (p *PDFDoc) callouttail(x, y, w, h, r, tx, ty float64, color string) {
+	var dx, dy float64
+	switch {
+	case tx < x:
+		dx = x - tx
+	case tx > x+w:
+		dx = tx - (x + w)
+	}
+	switch {
+	case ty < y:
+		dy = y - ty
+	case ty > y+h:
+		dy = ty - (y + h)
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	clamp := func(v, lo, hi float64) float64 {
+		if hi < lo {
+			return (lo + hi) / 2
+		}
+		return math.Max(lo, math.Min(v, hi))
+	}
+	if dy >= dx {
+		half := math.Min(w/2-r, h/3) / 2
+		cx := clamp(tx, x+r+half, x+w-r-half)
+		edge := y
+		if ty > y+h {
+			edge = y + h
+		}
+		p.Polygon([]float64{cx - half, tx, cx + half}, []float64{edge, ty, edge}, color)
+		return
+	}
+	half := math.Min(h/2-r, w/3) / 2
+	cy := clamp(ty, y+r+half, y+h-r-half)
+	edge := x
+	if tx > x+w {
+		edge = x + w
+	}
+	p.Polygon([]float64{edge, tx, edge}, []float64{cy - half, ty, cy + half}, color)
+}
@@ -0,0 +1,206 @@
+package pdfgen
+
+import (
+	"image"
+	"image/color"
+)
+
+// CCITT Group 4 (ITU-T T.6) encoding of bilevel images, for the CCITTFaxDecode filter.
+// Each row is coded relative to the row above (an imaginary white row for the first),
+// by the positions of the changing elements: pixels whose color differs from the one to their left.
+
+// Modified Huffman codes of white and black run lengths: terminating codes for 0 to 63,
+// and makeup codes for multiples of 64 up to 1728
+var (
+	whiteterm = [64]string{
+		"00110101", "000111", "0111", "1000", "1011", "1100", "1110", "1111",
+		"10011", "10100", "00111", "01000", "001000", "000011", "110100", "110101",
+		"101010", "101011", "0100111", "0001100", "0001000", "0010111", "0000011", "0000100",
+		"0101000", "0101011", "0010011", "0100100", "0011000", "00000010", "00000011", "00011010",
+		"00011011", "00010010", "00010011", "00010100", "00010101", "00010110", "00010111", "00101000",
+		"00101001", "00101010", "00101011", "00101100", "00101101", "00000100", "00000101", "00001010",
+		"00001011", "01010010", "01010011", "01010100", "01010101", "00100100", "00100101", "01011000",
+		"01011001", "01011010", "01011011", "01001010", "01001011", "00110010", "00110011", "00110100",
+	}
+	blackterm = [64]string{
+		"0000110111", "010", "11", "10", "011", "0011", "0010", "00011",
+		"000101", "000100", "0000100", "0000101", "0000111", "00000100", "00000111", "000011000",
+		"0000010111", "0000011000", "0000001000", "00001100111", "00001101000", "00001101100", "00000110111", "00000101000",
+		"00000010111", "00000011000", "000011001010", "000011001011", "000011001100", "000011001101", "000001101000", "000001101001",
+		"000001101010", "000001101011", "000011010010", "000011010011", "000011010100", "000011010101", "000011010110", "000011010111",
+		"000001101100", "000001101101", "000011011010", "000011011011", "000001010100", "000001010101", "000001010110", "000001010111",
+		"000001100100", "000001100101", "000001010010", "000001010011", "000000100100", "000000110111", "000000111000", "000000100111",
+		"000000101000", "000001011000", "000001011001", "000000101011", "000000101100", "000001011010", "000001100110", "000001100111",
+	}
+	whitemakeup = [27]string{
+		"11011", "10010", "010111", "0110111", "00110110", "00110111", "01100100", "01100101",
+		"01101000", "01100111", "011001100", "011001101", "011010010", "011010011", "011010100", "011010101",
+		"011010110", "011010111", "011011000", "011011001", "011011010", "011011011", "010011000", "010011001",
+		"010011010", "011000", "010011011",
+	}
+	blackmakeup = [27]string{
+		"0000001111", "000011001000", "000011001001", "000001011011", "000000110011", "000000110100", "000000110101", "0000001101100",
+		"0000001101101", "0000001001010", "0000001001011", "0000001001100", "0000001001101", "0000001110010", "0000001110011", "0000001110100",
+		"0000001110101", "0000001110110", "0000001110111", "0000001010010", "0000001010011", "0000001010100", "0000001010101", "0000001011010",
+		"0000001011011", "0000001100100", "0000001100101",
+	}
+	// extended makeup codes, shared by white and black runs, for 1792 to 2560
+	extmakeup = [13]string{
+		"00000001000", "00000001100", "00000001101", "000000010010", "000000010011", "000000010100", "000000010101",
+		"000000010110", "000000010111", "000000011100", "000000011101", "000000011110", "000000011111",
+	}
+)
+
+// Two-dimensional coding modes; vertical codes are indexed by a1-b1+3
+const (
+	passcode       = "0001"
+	horizontalcode = "001"
+	eofb           = "000000000001000000000001"
+)
+
+var verticalcode = [7]string{"0000010", "000010", "010", "1", "011", "000011", "0000011"}
+
+// bitwriter accumulates codes into bytes, most significant bit first.
+type bitwriter struct {
+	buf  []byte
+	cur  byte
+	nbit uint
+}
+
+// put appends the code, a string of 0 and 1 bits
+func (w *bitwriter) put(code string) {
+	for i := 0; i < len(code); i++ {
+		w.cur <<= 1
+		if code[i] == '1' {
+			w.cur |= 1
+		}
+		w.nbit++
+		if w.nbit == 8 {
+			w.buf = append(w.buf, w.cur)
+			w.cur, w.nbit = 0, 0
+		}
+	}
+}
+
+// bytes returns the coded data, padding the last byte with zero bits
+func (w *bitwriter) bytes() []byte {
+	if w.nbit > 0 {
+		w.buf = append(w.buf, w.cur<<(8-w.nbit))
+		w.cur, w.nbit = 0, 0
+	}
+	return w.buf
+}
+
+// run writes the codes of a run of n white or black pixels
+func (w *bitwriter) run(n int, black bool) {
+	term, makeup := whiteterm, whitemakeup
+	if black {
+		term, makeup = blackterm, blackmakeup
+	}
+	for n >= 2560+64 {
+		w.put(extmakeup[len(extmakeup)-1])
+		n -= 2560
+	}
+	if n >= 64 {
+		if m := n / 64; m >= 28 {
+			w.put(extmakeup[m-28])
+		} else {
+			w.put(makeup[m-1])
+		}
+		n %= 64
+	}
+	w.put(term[n])
+}
+
+// bilevel returns the rows of an image reduced to black (1) and white (0) pixels:
+// pixels darker than middle gray and at least half opaque are black
+func bilevel(img image.Image) [][]byte {
+	b := img.Bounds()
+	rows := make([][]byte, b.Dy())
+	for y := range rows {
+		row := make([]byte, b.Dx())
+		for x := range row {
+			c := img.At(b.Min.X+x, b.Min.Y+y)
+			if _, _, _, a := c.RGBA(); a < 0x8000 {
+				continue
+			}
+			if color.GrayModel.Convert(c).(color.Gray).Y < 0x80 {
+				row[x] = 1
+			}
+		}
+		rows[y] = row
+	}
+	return rows
+}
+
+// changing returns the position of the first changing element after position a
+// (-1 for the start of the row) whose color is the color c, or the row width if there is none
+func changing(row []byte, a int, c byte) int {
+	for i := a + 1; i < len(row); i++ {
+		prev := byte(0)
+		if i > 0 {
+			prev = row[i-1]
+		}
+		if row[i] == c && prev != c {
+			return i
+		}
+	}
+	return len(row)
+}
+
+// runend returns the position of the first pixel after position a whose color differs from
+// the color c, or the row width if there is none
+func runend(row []byte, a int, c byte) int {
+	for i := a + 1; i < len(row); i++ {
+		if row[i] != c {
+			return i
+		}
+	}
+	return len(row)
+}
+
+// encodeG4 returns rows of black (1) and white (0) pixels, all the same width,
+// coded with CCITT Group 4 (K -1) compression
+func encodeG4(rows [][]byte) []byte {
+	var w bitwriter
+	if len(rows) == 0 {
+		return nil
+	}
+	ref := make([]byte, len(rows[0]))
+	for _, row := range rows {
+		width := len(row)
+		a0, c := -1, byte(0)
+		for a0 < width {
+			a1 := runend(row, a0, c)
+			b1 := changing(ref, a0, 1-c)
+			b2 := width
+			if b1 < width {
+				b2 = runend(ref, b1, ref[b1])
+			}
+			switch {
+			case b2 < a1:
+				w.put(passcode)
+				a0 = b2
+			case a1-b1 >= -3 && a1-b1 <= 3:
+				w.put(verticalcode[a1-b1+3])
+				a0, c = a1, 1-c
+			default:
+				a2 := width
+				if a1 < width {
+					a2 = runend(row, a1, 1-c)
+				}
+				start := a0
+				if start < 0 {
+					start = 0
+				}
+				w.put(horizontalcode)
+				w.run(a1-start, c == 1)
+				w.run(a2-a1, c == 0)
+				a0 = a2
+			}
+		}
+		ref = row
+	}
+	w.put(eofb)
+	return w.bytes()
+}
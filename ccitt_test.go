@@ -0,0 +1,66 @@
+//go:build ccitt
+// +build ccitt
+
+package pdfgen
+
+// Testing with the ccitt tag decodes the Group 4 data of encodeG4 with golang.org/x/image/ccitt.
+
+import (
+	"bytes"
+	"image"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/ccitt"
+)
+
+// randombilevel returns h rows of w pixels, in runs whose lengths vary with density
+func randombilevel(r *rand.Rand, w, h int, density float64) [][]byte {
+	rows := make([][]byte, h)
+	for y := range rows {
+		rows[y] = make([]byte, w)
+		c := byte(r.Intn(2))
+		for x := range rows[y] {
+			if r.Float64() < density {
+				c = 1 - c
+			}
+			if y > 0 && r.Intn(4) == 0 { // often follow the row above, as scans do
+				c = rows[y-1][x]
+			}
+			rows[y][x] = c
+		}
+	}
+	return rows
+}
+
+func TestEncodeG4(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var cases [][][]byte
+	for _, wh := range [][2]int{{1, 1}, {1, 9}, {9, 1}, {64, 64}} {
+		for _, c := range []byte{0, 1} {
+			rows := make([][]byte, wh[1])
+			for y := range rows {
+				rows[y] = bytes.Repeat([]byte{c}, wh[0])
+			}
+			cases = append(cases, rows)
+		}
+	}
+	for i := 0; i < 300; i++ {
+		cases = append(cases, randombilevel(r, 1+r.Intn(300), 1+r.Intn(60), []float64{0.01, 0.1, 0.5, 0.9}[i%4]))
+	}
+	for i, rows := range cases {
+		w, h := len(rows[0]), len(rows)
+		dst := image.NewGray(image.Rect(0, 0, w, h))
+		err := ccitt.DecodeIntoGray(dst, bytes.NewReader(encodeG4(rows)), ccitt.MSB, ccitt.Group4, nil)
+		if err != nil {
+			t.Fatalf("case %d (%dx%d): %v", i, w, h, err)
+		}
+		for y, row := range rows {
+			for x, c := range row {
+				if want := uint8(0xff * (1 - c)); dst.GrayAt(x, y).Y != want {
+					t.Fatalf("case %d (%dx%d): pixel (%d,%d) is %#x; want %#x", i, w, h, x, y, dst.GrayAt(x, y).Y, want)
+				}
+			}
+		}
+	}
+}
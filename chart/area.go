@@ -0,0 +1,129 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Stacking is how the series of an area chart are combined.
+type Stacking int
+
+const (
+	// Overlap fills each series from zero, over the ones before it.
+	Overlap Stacking = iota
+	// Stacked fills each series on top of the ones before it.
+	Stacked
+	// Percent fills each series on top of the ones before it, as a percentage of their total.
+	Percent
+)
+
+// AreaChart fills the area beneath one or more series over shared x values,
+// overlapping or stacked, as for the composition of a total over time.
+type AreaChart struct {
+	X           []float64   // x values of the series' points; if empty, 1, 2, 3...
+	Series      []Series    // the y values of each area; missing values count as zero
+	Stack       Stacking    // how the series are combined
+	XAxis       pdfgen.Axis // x axis; the range is from the data if Min and Max are equal
+	YAxis       pdfgen.Axis // y axis; the range is from the data, or 0 to 100 for Percent, if Min and Max are equal
+	Opacity     float64     // opacity of the fills, as a percentage, default 60 for Overlap and 85 for stacked areas
+	StrokeWidth float64     // width of the line along the top of each area, default 1; none if negative
+	Legend      bool        // show a legend of the named series above the plot
+	Text        TextStyle   // style of the legend
+}
+
+// layers returns the bottom and top of each series' area at each point
+func (c AreaChart) layers(n int) (bottoms, tops [][]float64) {
+	base := make([]float64, n)
+	total := make([]float64, n)
+	for _, s := range c.Series {
+		for i := range total {
+			v, _ := s.value(i)
+			total[i] += v
+		}
+	}
+	for _, s := range c.Series {
+		bottom, top := make([]float64, n), make([]float64, n)
+		for i := range top {
+			v, _ := s.value(i)
+			switch c.Stack {
+			case Overlap:
+				top[i] = v
+			case Stacked:
+				bottom[i], top[i] = base[i], base[i]+v
+			case Percent:
+				if total[i] != 0 {
+					v = v / total[i] * 100
+				}
+				bottom[i], top[i] = base[i], base[i]+v
+			}
+			base[i] = top[i]
+		}
+		bottoms, tops = append(bottoms, bottom), append(tops, top)
+	}
+	return bottoms, tops
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c AreaChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := categories(nil, c.Series)
+	if n == 0 {
+		return
+	}
+	xs := xvalues(c.X, n)
+	bottoms, tops := c.layers(n)
+	ymin, ymax := 0.0, 0.0
+	for i := range tops {
+		for j := range tops[i] {
+			ymin, ymax = math.Min(ymin, math.Min(bottoms[i][j], tops[i][j])), math.Max(ymax, math.Max(bottoms[i][j], tops[i][j]))
+		}
+	}
+	ya := c.YAxis
+	if c.Stack == Percent && ya.Min == ya.Max {
+		ya.Min, ya.Max = 0, 100
+	}
+	xmin, xmax := extent([]Series{{Values: xs[:n]}})
+	xa := valueaxis(c.XAxis, xmin, xmax)
+	ya = valueaxis(ya, ymin, ymax)
+	opacity := c.Opacity
+	if opacity == 0 {
+		opacity = 85
+		if c.Stack == Overlap {
+			opacity = 60
+		}
+	}
+	sw := c.StrokeWidth
+	if sw == 0 {
+		sw = 1
+	}
+
+	p := plotarea(x, y, w, h, xa, ya)
+	if c.Legend {
+		l := Legend{Entries: SeriesEntries(doc, c.Series, SwatchKey), Text: c.Text}
+		p = plotarea(x, y, w, l.above(doc, x, y, w, h), xa, ya)
+	}
+	if p.w <= 0 || p.h <= 0 {
+		return
+	}
+	p.axes(doc, &xa, &ya)
+	for s, series := range c.Series {
+		color := series.color(doc, s)
+		px, py := make([]float64, 0, 2*n), make([]float64, 0, 2*n)
+		for i := 0; i < n; i++ {
+			ax, ay := p.point(xs[i], tops[s][i], xa, ya)
+			px, py = append(px, ax), append(py, ay)
+		}
+		for i := n - 1; i >= 0; i-- {
+			ax, ay := p.point(xs[i], bottoms[s][i], xa, ya)
+			px, py = append(px, ax), append(py, ay)
+		}
+		fill := color
+		if opacity < 100 {
+			fill += "/" + valueformat("", opacity)
+		}
+		doc.Polygon(px, py, fill)
+		if sw > 0 {
+			doc.Polyline(px[:n], py[:n], sw, color)
+		}
+	}
+}
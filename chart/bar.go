@@ -0,0 +1,108 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// BarChart is a vertical bar chart: for each category, a bar for each series,
+// rising from zero on a value axis at the left.
+type BarChart struct {
+	Categories []string    // category labels, below the bars
+	Series     []Series    // one bar per category for each series
+	Colors     []string    // colors of the bars by category, over the series color, for a single series
+	Axis       pdfgen.Axis // value axis; the range is from the data, including zero, if Min and Max are equal
+	Gap        float64     // fraction of each category's width left between its bars and the next, default 0.3
+	DataLabels bool        // label each bar with its value
+	Legend     bool        // show a legend of the named series above the plot
+	Format     string      // format of the data labels; if empty, up to two decimal places
+	Text       TextStyle   // style of the category and data labels
+}
+
+// barcolor returns the color of bar i of series n: by category for a single series with colors
+func barcolor(doc *pdfgen.PDFDoc, series []Series, colors []string, n, i int) string {
+	if len(series) == 1 && i < len(colors) && colors[i] != "" {
+		return colors[i]
+	}
+	return series[n].color(doc, n)
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c BarChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := categories(c.Categories, c.Series)
+	if n == 0 || len(c.Series) == 0 {
+		return
+	}
+	min, max := extent(c.Series)
+	a := valueaxis(c.Axis, math.Min(min, 0), math.Max(max, 0))
+	t := c.Text.defaults()
+	gap := c.Gap
+	if gap <= 0 || gap >= 1 {
+		gap = 0.3
+	}
+	if c.Legend {
+		h = Legend{Entries: SeriesEntries(doc, c.Series, SwatchKey), Text: c.Text}.above(doc, x, y, w, h)
+	}
+
+	// the plot, inside the axis labels, category labels, and data labels
+	left := labelwidth(a) + ticksize(a) + 4
+	band := (w - left) / float64(n)
+	lines := make([][]string, n)
+	nlines := 1
+	for i := range lines {
+		if i < len(c.Categories) {
+			lines[i] = pdfgen.WrapText(c.Categories[i], t.Font, t.FontSize, band-2)
+		}
+		if len(lines[i]) > nlines {
+			nlines = len(lines[i])
+		}
+	}
+	lead := t.FontSize * 1.2
+	bottom := float64(nlines)*lead + 4
+	top := 0.0
+	if c.DataLabels {
+		top = lead
+	}
+	px, py, pw, ph := x+left, y+bottom, w-left, h-bottom-top
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	if a.GridLength == 0 {
+		a.GridLength = pw
+	}
+	doc.YAxis(px, py, ph, a)
+	vy := func(v float64) float64 {
+		return py + pdfgen.MapRange(math.Max(a.Min, math.Min(a.Max, v)), a.Min, a.Max, 0, ph)
+	}
+	base := vy(0)
+
+	bw := band * (1 - gap) / float64(len(c.Series))
+	for i := 0; i < n; i++ {
+		bx := px + float64(i)*band + band*gap/2
+		for s, series := range c.Series {
+			v, ok := series.value(i)
+			if !ok {
+				bx += bw
+				continue
+			}
+			end := vy(v)
+			doc.Rect(bx, math.Min(base, end), bw, math.Abs(end-base), barcolor(doc, c.Series, c.Colors, s, i))
+			if c.DataLabels {
+				l := valueformat(c.Format, v)
+				ly := end + 2
+				if v < 0 {
+					ly = end - t.FontSize - 1
+				}
+				t.ctext(doc, bx+bw/2, ly, l)
+			}
+			bx += bw
+		}
+		ly := py - 4 - t.FontSize
+		for _, l := range lines[i] {
+			t.ctext(doc, px+(float64(i)+0.5)*band, ly, l)
+			ly -= lead
+		}
+	}
+	doc.Line(px, base, px+pw, base, 0.5, axisfont(a).Color)
+}
@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// BoxPlot shows the distribution of values in each category as a box and whiskers:
+// the box spans the quartiles, divided at the median, and the whiskers reach the most
+// extreme values within 1.5 times the box's height, beyond which outliers are marked.
+type BoxPlot struct {
+	Categories []string    // category labels, below the boxes
+	Data       [][]float64 // the values of each category
+	Axis       pdfgen.Axis // value axis; the range is from the data if Min and Max are equal
+	Width      float64     // fraction of each category's width taken by its box, default 0.5
+	Color      string      // fill color of the boxes, default the first series color
+	Line       string      // color of the outlines, median, whiskers, and outliers, default foreground
+	Text       TextStyle   // style of the category labels
+}
+
+// Draw draws the plot in the w by h rectangle with its lower left at (x,y)
+func (c BoxPlot) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := len(c.Data)
+	if len(c.Categories) > n {
+		n = len(c.Categories)
+	}
+	if n == 0 {
+		return
+	}
+	stats := make([]BoxStats, n)
+	series := make([]Series, len(c.Data))
+	for i := range stats {
+		var values []float64
+		if i < len(c.Data) {
+			values = c.Data[i]
+			series[i].Values = values
+		}
+		stats[i] = Summarize(values)
+	}
+	min, max := extent(series)
+	a := valueaxis(c.Axis, min, max)
+	t := c.Text.defaults()
+	bw := c.Width
+	if bw <= 0 || bw > 1 {
+		bw = 0.5
+	}
+	fill := Series{Color: c.Color}.color(doc, 0)
+	line := c.Line
+	if line == "" {
+		line = "foreground"
+	}
+
+	left := labelwidth(a) + ticksize(a) + 4
+	bottom := t.FontSize*1.2 + 4
+	top := axisfont(a).FontSize / 2
+	px, py, pw, ph := x+left, y+bottom, w-left, h-bottom-top
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	if a.GridLength == 0 {
+		a.GridLength = pw
+	}
+	doc.YAxis(px, py, ph, a)
+	doc.Line(px, py, px+pw, py, 0.5, axisfont(a).Color)
+	vy := func(v float64) float64 { return py + pdfgen.MapRange(v, a.Min, a.Max, 0, ph) }
+	band := pw / float64(n)
+	for i, b := range stats {
+		cx := px + (float64(i)+0.5)*band
+		if i < len(c.Categories) {
+			t.ctext(doc, cx, py-4-t.FontSize, c.Categories[i])
+		}
+		if math.IsNaN(b.Median) {
+			continue
+		}
+		half := band * bw / 2
+		doc.Line(cx, vy(b.Low), cx, vy(b.Q1), 0.75, line)
+		doc.Line(cx, vy(b.Q3), cx, vy(b.High), 0.75, line)
+		doc.Line(cx-half/2, vy(b.Low), cx+half/2, vy(b.Low), 0.75, line)
+		doc.Line(cx-half/2, vy(b.High), cx+half/2, vy(b.High), 0.75, line)
+		doc.FillStrokeRect(cx-half, vy(b.Q1), 2*half, vy(b.Q3)-vy(b.Q1), 0.75, fill, line)
+		doc.Line(cx-half, vy(b.Median), cx+half, vy(b.Median), 1.5, line)
+		for _, v := range b.Outliers {
+			doc.StrokeCircle(cx, vy(v), 2, 0.75, line)
+		}
+	}
+}
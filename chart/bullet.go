@@ -0,0 +1,101 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// BulletGraph is a bullet graph, after Stephen Few: a measure, shown by a bar, against
+// a target, shown by a tick, over bands of qualitative ranges (for example poor, fair, good).
+type BulletGraph struct {
+	Label       string      // name of the measure, at the left
+	Sublabel    string      // units or description, below the label
+	Value       float64     // the measure
+	Target      float64     // the target; none if zero
+	Ranges      []float64   // upper bounds of the qualitative ranges, worst first; the last ends the scale
+	Min         float64     // start of the scale
+	Colors      []string    // colors of the ranges; if empty, grays from dark to light
+	Color       string      // color of the measure bar, default foreground
+	TargetColor string      // color of the target tick, default foreground
+	Axis        pdfgen.Axis // scale below the graph; the range is Min to the last range if Min and Max are equal
+	LabelWidth  float64     // width of the labels at the left, default the width of the widest
+	Text        TextStyle   // style of the label; the sublabel is smaller
+}
+
+// rangecolor returns the color of range i of n
+func (b BulletGraph) rangecolor(i, n int) string {
+	if i < len(b.Colors) && b.Colors[i] != "" {
+		return b.Colors[i]
+	}
+	if n < 2 {
+		return "#cccccc"
+	}
+	return pdfgen.InterpolateColor("#999999", "#e6e6e6", float64(i)/float64(n-1))
+}
+
+// Draw draws the graph in the w by h rectangle with its lower left at (x,y)
+func (b BulletGraph) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	t := b.Text.defaults()
+	sub := t
+	sub.FontSize *= 0.8
+	sub.Color = "muted"
+	max := b.Value
+	for _, r := range b.Ranges {
+		max = math.Max(max, r)
+	}
+	a := b.Axis
+	if a.Min == a.Max {
+		a.Min, a.Max = b.Min, max
+	}
+	a = valueaxis(a, a.Min, a.Max)
+	lw := b.LabelWidth
+	if lw == 0 {
+		lw = math.Max(t.width(b.Label), sub.width(b.Sublabel))
+	}
+	if lw > 0 {
+		lw += 8
+	}
+	bottom := ticksize(a) + axisfont(a).FontSize*1.5
+	// room for half the last scale label beyond the graph
+	right := axisfont(a).width(a.Label(a.Max)) / 2
+	px, py, pw, ph := x+lw, y+bottom, w-lw-right, h-bottom
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	vx := func(v float64) float64 {
+		return px + pdfgen.MapRange(math.Max(a.Min, math.Min(a.Max, v)), a.Min, a.Max, 0, pw)
+	}
+
+	// labels, right aligned to the graph and centered on it
+	cy := py + ph/2
+	if b.Sublabel == "" {
+		t.etext(doc, px-8, cy-t.FontSize/3, b.Label)
+	} else {
+		t.etext(doc, px-8, cy+1, b.Label)
+		sub.etext(doc, px-8, cy-sub.FontSize-1, b.Sublabel)
+	}
+
+	// the ranges, then the measure and target over them
+	from := a.Min
+	for i, r := range b.Ranges {
+		doc.Rect(vx(from), py, vx(r)-vx(from), ph, b.rangecolor(i, len(b.Ranges)))
+		from = r
+	}
+	color := b.Color
+	if color == "" {
+		color = "foreground"
+	}
+	base := vx(math.Max(a.Min, 0))
+	end := vx(b.Value)
+	doc.Rect(math.Min(base, end), py+ph/3, math.Abs(end-base), ph/3, color)
+	if b.Target != 0 {
+		tc := b.TargetColor
+		if tc == "" {
+			tc = "foreground"
+		}
+		tx := vx(b.Target)
+		doc.Line(tx, py+ph*0.15, tx, py+ph*0.85, math.Max(1.5, ph/12), tc)
+	}
+	doc.XAxis(px, py, pw, a)
+}
@@ -0,0 +1,168 @@
+// Package chart draws data charts on pdfgen documents.
+//
+// Each chart is a struct describing the data and its presentation, drawn by its Draw
+// method into a rectangle of the page, given by its lower left corner, width and height.
+// The rectangle includes the axes and their labels.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Series is a named sequence of values.
+type Series struct {
+	Name   string
+	Values []float64
+	Color  string // color of the series; if empty, the theme's series color, by position
+}
+
+// color returns the color of series i, counting from 0
+func (s Series) color(doc *pdfgen.PDFDoc, i int) string {
+	if s.Color != "" {
+		return s.Color
+	}
+	return doc.Theme().SeriesColor(i + 1)
+}
+
+// TextStyle describes the text of a chart's labels.
+type TextStyle struct {
+	Font     string  // default sans
+	FontSize float64 // default 8
+	Color    string  // default foreground, the theme's text color
+}
+
+// defaults fills in the default values
+func (t TextStyle) defaults() TextStyle {
+	if t.Font == "" {
+		t.Font = "sans"
+	}
+	if t.FontSize == 0 {
+		t.FontSize = 8
+	}
+	if t.Color == "" {
+		t.Color = "foreground"
+	}
+	return t
+}
+
+// width returns the width of s in the style
+func (t TextStyle) width(s string) float64 {
+	return pdfgen.TextWidth(s, t.Font, t.FontSize)
+}
+
+// text draws s with its baseline starting at (x,y)
+func (t TextStyle) text(doc *pdfgen.PDFDoc, x, y float64, s string) {
+	doc.Text(x, y, s, t.Font, t.FontSize, t.Color)
+}
+
+// ctext draws s centered at x
+func (t TextStyle) ctext(doc *pdfgen.PDFDoc, x, y float64, s string) {
+	t.text(doc, x-t.width(s)/2, y, s)
+}
+
+// etext draws s ending at x
+func (t TextStyle) etext(doc *pdfgen.PDFDoc, x, y float64, s string) {
+	t.text(doc, x-t.width(s), y, s)
+}
+
+// radial draws s by (x,y), at the end of a radius at the angle (in radians),
+// set away from the center
+func (t TextStyle) radial(doc *pdfgen.PDFDoc, x, y, angle float64, s string) {
+	switch cos := math.Cos(angle); {
+	case cos > 0.1:
+		t.text(doc, x, y-t.FontSize/3, s)
+	case cos < -0.1:
+		t.etext(doc, x, y-t.FontSize/3, s)
+	case math.Sin(angle) > 0:
+		t.ctext(doc, x, y, s)
+	default:
+		t.ctext(doc, x, y-t.FontSize, s)
+	}
+}
+
+// valueformat formats a data value: with the format if there is one,
+// or else with up to two decimal places
+func valueformat(format string, v float64) string {
+	if format != "" {
+		return fmt.Sprintf(format, v)
+	}
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// extent returns the least and greatest of the values of the series
+func extent(series []Series) (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, v := range s.Values {
+			if math.IsNaN(v) {
+				continue
+			}
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+	}
+	if min > max {
+		return 0, 1
+	}
+	return min, max
+}
+
+// valueaxis returns the axis with its range and step filled in: if Min and Max are
+// equal, a range of round numbers covering min to max
+func valueaxis(a pdfgen.Axis, min, max float64) pdfgen.Axis {
+	if a.Min == a.Max {
+		a.Min, a.Max, a.Step = pdfgen.NiceTicks(min, max, 6)
+	}
+	if a.Step <= 0 {
+		_, _, a.Step = pdfgen.NiceTicks(a.Min, a.Max, 6)
+	}
+	return a
+}
+
+// axisfont returns the style of an axis's labels
+func axisfont(a pdfgen.Axis) TextStyle {
+	if a.Color == "" {
+		a.Color = "foreground"
+	}
+	return TextStyle{Font: a.Font, FontSize: a.FontSize, Color: a.Color}.defaults()
+}
+
+// labelwidth returns the width of the widest tick label of the axis
+func labelwidth(a pdfgen.Axis) float64 {
+	t := axisfont(a)
+	w := 0.0
+	for _, v := range pdfgen.Ticks(a.Min, a.Max, a.Step) {
+		w = math.Max(w, t.width(a.Label(v)))
+	}
+	return w
+}
+
+// ticksize returns the length of the axis's tick marks
+func ticksize(a pdfgen.Axis) float64 {
+	if a.TickSize == 0 {
+		return 4
+	}
+	return a.TickSize
+}
+
+// categories returns the number of categories: of the labels, or of the longest series
+func categories(labels []string, series []Series) int {
+	n := len(labels)
+	for _, s := range series {
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+	}
+	return n
+}
+
+// value returns value i of the series, and whether it has one
+func (s Series) value(i int) (float64, bool) {
+	if i >= len(s.Values) || math.IsNaN(s.Values[i]) {
+		return 0, false
+	}
+	return s.Values[i], true
+}
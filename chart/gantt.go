@@ -0,0 +1,177 @@
+package chart
+
+import (
+	"math"
+	"time"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Task is a bar of a Gantt chart.
+type Task struct {
+	Name       string
+	Lane       string // swimlane of the task; tasks in the same lane are drawn together
+	Start, End time.Time
+	Progress   float64 // fraction of the task complete, shown as a darker part of its bar
+	Color      string  // color of the bar; if empty, the theme's series color for its lane
+}
+
+// GanttChart shows tasks as bars along a time axis, one row each, grouped in swimlanes.
+type GanttChart struct {
+	Tasks      []Task
+	Start, End time.Time // range of the time axis; from the tasks if zero
+	Today      time.Time // time of a vertical line marking the present; none if zero
+	TodayColor string    // color of the today line, default red
+	LaneColor  string    // background of alternate lanes, default whitesmoke
+	Gap        float64   // fraction of each row's height left between bars, default 0.3
+	Text       TextStyle // style of the names and dates
+}
+
+// lanes returns the indexes of the tasks, grouped by lane in order of each lane's first task,
+// and the lane names
+func (c GanttChart) lanes() ([][]int, []string) {
+	var groups [][]int
+	var names []string
+	index := map[string]int{}
+	for i, t := range c.Tasks {
+		n, ok := index[t.Lane]
+		if !ok {
+			n = len(groups)
+			index[t.Lane] = n
+			groups, names = append(groups, nil), append(names, t.Lane)
+		}
+		groups[n] = append(groups[n], i)
+	}
+	return groups, names
+}
+
+// timeticks returns times at round intervals within the range, and their label format:
+// days, weeks (Mondays), months, or years, as the span suits
+func timeticks(start, end time.Time) ([]time.Time, string) {
+	span := end.Sub(start).Hours() / 24
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	var t time.Time
+	var next func(time.Time) time.Time
+	format := "Jan 2"
+	switch {
+	case span <= 16:
+		t, next = day, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case span <= 120:
+		t = day.AddDate(0, 0, (8-int(day.Weekday()))%7)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case span <= 900:
+		t, format = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()), "Jan"
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		t, format = time.Date(start.Year(), 1, 1, 0, 0, 0, 0, start.Location()), "2006"
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	}
+	var ticks []time.Time
+	for ; !t.After(end); t = next(t) {
+		if !t.Before(start) {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks, format
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c GanttChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	if len(c.Tasks) == 0 {
+		return
+	}
+	start, end := c.Start, c.End
+	for _, t := range c.Tasks {
+		if c.Start.IsZero() && (start.IsZero() || t.Start.Before(start)) {
+			start = t.Start
+		}
+		if c.End.IsZero() && (end.IsZero() || t.End.After(end)) {
+			end = t.End
+		}
+	}
+	if !end.After(start) {
+		end = start.Add(24 * time.Hour)
+	}
+	t := c.Text.defaults()
+	gap := c.Gap
+	if gap <= 0 || gap >= 1 {
+		gap = 0.3
+	}
+	lanecolor := c.LaneColor
+	if lanecolor == "" {
+		lanecolor = "whitesmoke"
+	}
+	groups, names := c.lanes()
+
+	// columns of lane and task names, then the timeline, below its date labels
+	lanew, namew := 0.0, 0.0
+	for i, g := range groups {
+		lanew = math.Max(lanew, t.width(names[i]))
+		for _, k := range g {
+			namew = math.Max(namew, t.width(c.Tasks[k].Name))
+		}
+	}
+	if lanew > 0 {
+		lanew += 8
+	}
+	namew += 8
+	top := t.FontSize * 1.5
+	px, pw, ph := x+lanew+namew, w-lanew-namew, h-top
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	total := float64(end.Sub(start))
+	tx := func(tm time.Time) float64 {
+		return px + pw*math.Max(0, math.Min(1, float64(tm.Sub(start))/total))
+	}
+	row := ph / float64(len(c.Tasks))
+
+	// alternate lanes shaded, and gridlines at the dates down the timeline, under the bars
+	ry := y + ph
+	for l, g := range groups {
+		lh := row * float64(len(g))
+		if l%2 == 1 {
+			doc.Rect(x, ry-lh, w, lh, lanecolor)
+		}
+		if l > 0 {
+			doc.Line(x, ry, x+w, ry, 0.5, "lightgray")
+		}
+		ry -= lh
+	}
+	ticks, format := timeticks(start, end)
+	for _, tm := range ticks {
+		gx := tx(tm)
+		doc.Line(gx, y, gx, y+ph, 0.25, "lightgray")
+		t.ctext(doc, gx, y+ph+t.FontSize*0.5, tm.Format(format))
+	}
+	doc.Line(px, y+ph, px+pw, y+ph, 0.5, t.Color)
+
+	ry = y + ph // top of the current row
+	for l, g := range groups {
+		lh := row * float64(len(g))
+		t.text(doc, x, ry-lh/2-t.FontSize/3, names[l])
+		color := doc.Theme().SeriesColor(l + 1)
+		for _, k := range g {
+			task := c.Tasks[k]
+			t.text(doc, x+lanew, ry-row/2-t.FontSize/3, task.Name)
+			bc := color
+			if task.Color != "" {
+				bc = task.Color
+			}
+			bx, bh := tx(task.Start), row*(1-gap)
+			bw := tx(task.End) - bx
+			doc.Rect(bx, ry-row+row*gap/2, bw, bh, bc+"/60")
+			if task.Progress > 0 {
+				doc.Rect(bx, ry-row+row*gap/2, bw*math.Min(1, task.Progress), bh, bc)
+			}
+			ry -= row
+		}
+	}
+	if !c.Today.IsZero() && !c.Today.Before(start) && !c.Today.After(end) {
+		today := c.TodayColor
+		if today == "" {
+			today = "red"
+		}
+		doc.Line(tx(c.Today), y, tx(c.Today), y+ph, 1, today)
+	}
+}
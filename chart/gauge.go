@@ -0,0 +1,102 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// GaugeRange is a colored band of a gauge's scale, up to a value.
+type GaugeRange struct {
+	To    float64
+	Color string
+}
+
+// Gauge is a dial: a needle pointing to a value on a circular scale, over colored ranges.
+type Gauge struct {
+	Value     float64
+	Scale     pdfgen.Axis  // range and ticks of the scale, default 0 to 100
+	Ranges    []GaugeRange // bands of the scale, each from the end of the one before, or the start of the scale
+	Track     string       // color of the scale where there are no ranges, default lightgray
+	Sweep     float64      // angle spanned by the scale, in degrees, default 240
+	Thickness float64      // width of the scale as a fraction of the radius, default 0.2
+	Needle    string       // color of the needle, default foreground
+	Label     string       // name of the value, below it
+	Format    string       // format of the value; if empty, up to two decimal places
+	Text      TextStyle    // style of the value, which is three times larger, and label
+}
+
+// Draw draws the gauge in the w by h rectangle with its lower left at (x,y)
+func (g Gauge) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	a := g.Scale
+	if a.Min == a.Max {
+		a.Min, a.Max = 0, 100
+	}
+	a = valueaxis(a, a.Min, a.Max)
+	t := g.Text.defaults()
+	big := t
+	big.FontSize *= 3
+	sweep := g.Sweep
+	if sweep <= 0 || sweep > 360 {
+		sweep = 240
+	}
+	thick := g.Thickness
+	if thick <= 0 || thick >= 1 {
+		thick = 0.2
+	}
+	track := g.Track
+	if track == "" {
+		track = "lightgray"
+	}
+	needle := g.Needle
+	if needle == "" {
+		needle = "foreground"
+	}
+
+	// the dial, centered across, and below it the value and label
+	below := math.Max(0, math.Sin((sweep/2-90)*math.Pi/180)) // extent below the center, as a fraction of the radius
+	text := big.FontSize * 1.2
+	if g.Label != "" {
+		text += t.FontSize * 1.5
+	}
+	r := math.Min(w/2, (h-text)/(1+below))
+	if r <= 0 {
+		return
+	}
+	cx, cy := x+w/2, y+h-r
+	start := 90 + sweep/2
+	angle := func(v float64) float64 {
+		return start - pdfgen.MapRange(math.Max(a.Min, math.Min(a.Max, v)), a.Min, a.Max, 0, sweep)
+	}
+	inner := r * (1 - thick)
+
+	doc.RingSegment(cx, cy, inner, r, start-sweep, start, track)
+	from := a.Min
+	for _, band := range g.Ranges {
+		doc.RingSegment(cx, cy, inner, r, angle(band.To), angle(from), band.Color)
+		from = band.To
+	}
+	af := axisfont(a)
+	for _, v := range pdfgen.Ticks(a.Min, a.Max, a.Step) {
+		rad := angle(v) * math.Pi / 180
+		cos, sin := math.Cos(rad), math.Sin(rad)
+		doc.Line(cx+inner*cos, cy+inner*sin, cx+(inner-ticksize(a))*cos, cy+(inner-ticksize(a))*sin, 0.75, af.Color)
+		lr := inner - ticksize(a) - 2
+		af.radial(doc, cx+lr*cos, cy+lr*sin, rad+math.Pi, a.Label(v))
+	}
+
+	// the needle, from the hub to the middle of the scale
+	rad := angle(g.Value) * math.Pi / 180
+	nl, nw := r*(1-thick/2), r*0.04
+	doc.Polygon(
+		[]float64{cx + nl*math.Cos(rad), cx + nw*math.Cos(rad+math.Pi/2), cx + nw*math.Cos(rad-math.Pi/2)},
+		[]float64{cy + nl*math.Sin(rad), cy + nw*math.Sin(rad+math.Pi/2), cy + nw*math.Sin(rad-math.Pi/2)},
+		needle)
+	doc.Circle(cx, cy, nw*1.5, needle)
+
+	ty := cy - math.Max(below*r, nw*1.5) - big.FontSize
+	big.ctext(doc, cx, ty, valueformat(g.Format, g.Value))
+	if g.Label != "" {
+		t.ctext(doc, cx, ty-t.FontSize*1.5, g.Label)
+	}
+}
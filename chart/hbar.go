@@ -0,0 +1,138 @@
+package chart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Order is the order of a chart's categories.
+type Order int
+
+const (
+	// Unsorted keeps the categories in the order given.
+	Unsorted Order = iota
+	// Ascending sorts the categories by the values of the first series, least first.
+	Ascending
+	// Descending sorts the categories by the values of the first series, greatest first.
+	Descending
+)
+
+// HBarChart is a horizontal bar chart: for each category, a bar for each series,
+// extending from zero along a value axis at the bottom, with the category labels at the left.
+type HBarChart struct {
+	Categories []string    // category labels, left of the bars, from the top down
+	Series     []Series    // one bar per category for each series
+	Colors     []string    // colors of the bars by category, over the series color, for a single series
+	Axis       pdfgen.Axis // value axis; the range is from the data, including zero, if Min and Max are equal
+	Gap        float64     // fraction of each category's height left between its bars and the next, default 0.3
+	Sort       Order       // order of the categories
+	LabelWidth float64     // greatest width of the category labels, which wrap within it, default 40% of the chart
+	DataLabels bool        // label each bar with its value
+	Format     string      // format of the data labels; if empty, up to two decimal places
+	Text       TextStyle   // style of the category and data labels
+}
+
+// order returns the indexes of the categories, in the chart's order
+func (c HBarChart) order(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	if c.Sort == Unsorted {
+		return idx
+	}
+	key := func(i int) float64 {
+		v, _ := c.Series[0].value(i)
+		return v
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		if c.Sort == Descending {
+			return key(idx[i]) > key(idx[j])
+		}
+		return key(idx[i]) < key(idx[j])
+	})
+	return idx
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c HBarChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := categories(c.Categories, c.Series)
+	if n == 0 || len(c.Series) == 0 {
+		return
+	}
+	min, max := extent(c.Series)
+	a := valueaxis(c.Axis, math.Min(min, 0), math.Max(max, 0))
+	t := c.Text.defaults()
+	gap := c.Gap
+	if gap <= 0 || gap >= 1 {
+		gap = 0.3
+	}
+	lw := c.LabelWidth
+	if lw <= 0 {
+		lw = w * 0.4
+	}
+
+	// the category labels, wrapped, set the left of the plot
+	lines := make([][]string, n)
+	left := 0.0
+	for i := range lines {
+		if i < len(c.Categories) {
+			lines[i] = pdfgen.WrapText(c.Categories[i], t.Font, t.FontSize, lw)
+		}
+		for _, l := range lines[i] {
+			left = math.Max(left, t.width(l))
+		}
+	}
+	left += 6
+	right := 0.0
+	if c.DataLabels {
+		right = t.width(valueformat(c.Format, max)) + 4
+	}
+	af := axisfont(a)
+	bottom := ticksize(a) + af.FontSize*1.5
+	px, py, pw, ph := x+left, y+bottom, w-left-right, h-bottom
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	if a.GridLength == 0 {
+		a.GridLength = ph
+	}
+	doc.XAxis(px, py, pw, a)
+	vx := func(v float64) float64 {
+		return px + pdfgen.MapRange(math.Max(a.Min, math.Min(a.Max, v)), a.Min, a.Max, 0, pw)
+	}
+	base := vx(0)
+
+	band := ph / float64(n)
+	bh := band * (1 - gap) / float64(len(c.Series))
+	lead := t.FontSize * 1.2
+	for k, i := range c.order(n) {
+		btop := py + ph - float64(k)*band - band*gap/2
+		for s, series := range c.Series {
+			v, ok := series.value(i)
+			if ok {
+				end := vx(v)
+				doc.Rect(math.Min(base, end), btop-bh, math.Abs(end-base), bh, barcolor(doc, c.Series, c.Colors, s, i))
+				if c.DataLabels {
+					ly := btop - bh/2 - t.FontSize/3
+					if l := valueformat(c.Format, v); v < 0 {
+						t.etext(doc, end-2, ly, l)
+					} else {
+						t.text(doc, end+2, ly, l)
+					}
+				}
+			}
+			btop -= bh
+		}
+		// labels centered on the band, right aligned to the plot
+		cy := py + ph - (float64(k)+0.5)*band
+		ly := cy + float64(len(lines[i])-1)*lead/2 - t.FontSize/3
+		for _, l := range lines[i] {
+			t.etext(doc, px-6, ly, l)
+			ly -= lead
+		}
+	}
+	doc.Line(base, py, base, py+ph, 0.5, af.Color)
+}
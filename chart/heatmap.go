@@ -0,0 +1,146 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Heatmap shows a matrix of values as a grid of cells colored by a ramp.
+type Heatmap struct {
+	Values     [][]float64 // rows of values, from the top; missing values leave their cells empty
+	Rows       []string    // labels of the rows, at the left
+	Columns    []string    // labels of the columns, below
+	Ramp       pdfgen.Ramp // colors of the values, from least to greatest, default Viridis
+	Min, Max   float64     // range of the values mapped to the ramp; from the data if equal
+	Diverging  bool        // map Mid to the middle of the ramp, for diverging ramps
+	Mid        float64     // value at the middle of a diverging ramp
+	Gap        float64     // space between cells
+	CellLabels bool        // label each cell with its value
+	Format     string      // format of the cell labels; if empty, up to two decimal places
+	Scale      bool        // show a color scale, with labeled values, at the right
+	Text       TextStyle   // style of the labels
+}
+
+// color returns the color of v
+func (c Heatmap) color(v, min, max float64) string {
+	if c.Diverging {
+		return c.Ramp.Diverging(v, min, c.Mid, max)
+	}
+	return c.Ramp.Value(v, min, max)
+}
+
+// Draw draws the heatmap in the w by h rectangle with its lower left at (x,y)
+func (c Heatmap) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	nr := len(c.Values)
+	nc := len(c.Columns)
+	series := make([]Series, nr)
+	for i, row := range c.Values {
+		nc = int(math.Max(float64(nc), float64(len(row))))
+		series[i].Values = row
+	}
+	if nr == 0 || nc == 0 {
+		return
+	}
+	if len(c.Ramp) == 0 {
+		c.Ramp = pdfgen.Viridis
+	}
+	min, max := c.Min, c.Max
+	if min == max {
+		min, max = extent(series)
+	}
+	if min == max {
+		min, max = min-1, max+1
+	}
+	t := c.Text.defaults()
+
+	left := 0.0
+	for _, l := range c.Rows {
+		left = math.Max(left, t.width(l))
+	}
+	if left > 0 {
+		left += 6
+	}
+	bottom := 0.0
+	if len(c.Columns) > 0 {
+		bottom = t.FontSize * 1.5
+	}
+	right := 0.0
+	sa := valueaxis(pdfgen.Axis{Font: t.Font, FontSize: t.FontSize, Color: t.Color}, min, max)
+	if c.Scale {
+		right = 10 + 12 + labelwidth(sa) + 6
+	}
+	px, py, pw, ph := x+left, y+bottom, w-left-right, h-bottom
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+	cw, ch := pw/float64(nc), ph/float64(nr)
+
+	for i, row := range c.Values {
+		cy := py + ph - float64(i+1)*ch
+		if i < len(c.Rows) {
+			t.etext(doc, px-6, cy+ch/2-t.FontSize/3, c.Rows[i])
+		}
+		for j, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			cx := px + float64(j)*cw
+			color := c.color(v, min, max)
+			doc.Rect(cx+c.Gap/2, cy+c.Gap/2, cw-c.Gap, ch-c.Gap, color)
+			if c.CellLabels {
+				lt := t
+				lt.Color = contrast(color)
+				lt.ctext(doc, cx+cw/2, cy+ch/2-t.FontSize/3, valueformat(c.Format, v))
+			}
+		}
+	}
+	for j, l := range c.Columns {
+		t.ctext(doc, px+(float64(j)+0.5)*cw, py-t.FontSize*1.2, l)
+	}
+
+	if c.Scale {
+		sx := px + pw + 12
+		stops := make([]pdfgen.GradientStop, len(c.Ramp))
+		for i := range stops {
+			stops[i].Offset = float64(i) / math.Max(1, float64(len(stops)-1))
+			stops[i].Color = c.Ramp[i]
+		}
+		if c.Diverging {
+			// the middle of the ramp is at Mid, not halfway up the scale
+			for i := range stops {
+				o := stops[i].Offset
+				v := min + o*2*(c.Mid-min)
+				if o > 0.5 {
+					v = c.Mid + (o*2-1)*(max-c.Mid)
+				}
+				stops[i].Offset = (v - min) / (max - min)
+			}
+		}
+		doc.GradientRect(sx, py, 10, ph, pdfgen.LinearGradient(sx, py, sx, py+ph, stops))
+		sa.Min, sa.Max = min, max
+		ticks := pdfgen.Ticks(min, max, sa.Step)
+		for _, v := range ticks {
+			ty := py + pdfgen.MapRange(v, min, max, 0, ph)
+			doc.Line(sx+10, ty, sx+13, ty, 0.5, t.Color)
+			t.text(doc, sx+15, ty-t.FontSize/3, sa.Label(v))
+		}
+	}
+}
+
+// contrast returns black or white, whichever is more legible on the hex color
+func contrast(color string) string {
+	if len(color) < 7 || color[0] != '#' {
+		return "black"
+	}
+	var rgb [3]float64
+	for i := range rgb {
+		v, _ := strconv.ParseUint(color[1+2*i:3+2*i], 16, 8)
+		rgb[i] = float64(v) / 255
+	}
+	if 0.299*rgb[0]+0.587*rgb[1]+0.114*rgb[2] < 0.55 {
+		return "white"
+	}
+	return "black"
+}
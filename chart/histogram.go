@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Binning is a rule for choosing the bins of a histogram.
+type Binning int
+
+const (
+	// Sturges takes log2(n)+1 bins, for n values, with round edges.
+	Sturges Binning = iota
+	// FreedmanDiaconis takes bins 2 IQR / cube root of n wide, with round edges;
+	// it suits skewed data and large samples better.
+	FreedmanDiaconis
+	// FixedBins divides the range of the data into a given number of equal bins.
+	FixedBins
+)
+
+// Bin sorts the values into bins by the rule, returning the edges of the bins (one more than
+// the bins) and the count of each. With FixedBins, n is the number of bins; otherwise it is ignored.
+// Each bin holds the values from its lower edge up to, but excluding, its upper edge;
+// the last also holds its upper edge.
+func Bin(values []float64, rule Binning, n int) ([]float64, []int) {
+	s := sorted(values)
+	if len(s) == 0 {
+		return nil, nil
+	}
+	min, max := s[0], s[len(s)-1]
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+	var edges []float64
+	switch rule {
+	case FixedBins:
+		if n < 1 {
+			n = 10
+		}
+		edges = make([]float64, n+1)
+		for i := range edges {
+			edges[i] = min + (max-min)*float64(i)/float64(n)
+		}
+	default:
+		k := math.Ceil(math.Log2(float64(len(s)))) + 1
+		if rule == FreedmanDiaconis {
+			if iqr := quantile(s, 0.75) - quantile(s, 0.25); iqr > 0 {
+				k = math.Ceil((max - min) / (2 * iqr / math.Cbrt(float64(len(s)))))
+			}
+		}
+		lo, hi, step := pdfgen.NiceTicks(min, max, int(k)+1)
+		edges = pdfgen.Ticks(lo, hi, step)
+		if len(edges) < 2 || edges[len(edges)-1] < max {
+			edges = append(edges, hi+step)
+		}
+	}
+	counts := make([]int, len(edges)-1)
+	b := 0
+	for _, v := range s {
+		for b < len(counts)-1 && v >= edges[b+1] {
+			b++
+		}
+		counts[b]++
+	}
+	return edges, counts
+}
+
+// Histogram is a bar chart of the distribution of values, sorted into bins.
+type Histogram struct {
+	Data    []float64   // the values
+	Bins    Binning     // rule choosing the bins
+	Count   int         // number of bins, for FixedBins, default 10
+	Color   string      // color of the bars, default the first series color
+	Outline string      // color of the lines between bars, default background (white on an unpainted page)
+	XAxis   pdfgen.Axis // value axis; the range is that of the bins if Min and Max are equal
+	YAxis   pdfgen.Axis // count axis; the range is from zero to the greatest count if Min and Max are equal
+}
+
+// Draw draws the histogram in the w by h rectangle with its lower left at (x,y)
+func (c Histogram) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	edges, counts := Bin(c.Data, c.Bins, c.Count)
+	if len(counts) == 0 {
+		return
+	}
+	most := 0
+	for _, n := range counts {
+		if n > most {
+			most = n
+		}
+	}
+	xa := c.XAxis
+	if xa.Min == xa.Max {
+		xa.Min, xa.Max = edges[0], edges[len(edges)-1]
+	}
+	xa = valueaxis(xa, xa.Min, xa.Max)
+	ya := valueaxis(c.YAxis, 0, float64(most))
+	if c.YAxis.Step == 0 && ya.Step < 1 {
+		ya.Step = 1 // counts are whole numbers
+	}
+	color := Series{Color: c.Color}.color(doc, 0)
+	outline := c.Outline
+	if outline == "" {
+		outline = "background"
+	}
+
+	p := plotarea(x, y, w, h, xa, ya)
+	if p.w <= 0 || p.h <= 0 {
+		return
+	}
+	p.axes(doc, &xa, &ya)
+	for i, n := range counts {
+		if n == 0 {
+			continue
+		}
+		x1, y1 := p.point(edges[i], 0, xa, ya)
+		x2, y2 := p.point(edges[i+1], float64(n), xa, ya)
+		doc.FillStrokeRect(x1, y1, x2-x1, y2-y1, 0.5, color, outline)
+	}
+}
@@ -0,0 +1,98 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// DataLabel is a text label for a data point at (X,Y) on the page.
+type DataLabel struct {
+	X, Y float64
+	Text string
+}
+
+// PlacedLabel is a data label in its place: the box of its text, with its lower left at (BX,BY),
+// and whether it was moved away from its point, to be joined to it by a leader line.
+type PlacedLabel struct {
+	DataLabel
+	BX, BY, W, H float64
+	Moved        bool
+}
+
+// Labeler places data labels beside their points, without covering each other or the points,
+// for charts built from the package's charts or from primitives.
+// A label is tried above, right of, below, and left of its point, then on the diagonals;
+// failing those, the same directions further away, with a leader line back to the point.
+type Labeler struct {
+	Text   TextStyle
+	Offset float64 // distance between a point and its label, default 3
+	Leader string  // color of the leader lines, default muted
+}
+
+// labeldirs are the directions tried from a point, in order
+var labeldirs = [8][2]float64{{0, 1}, {1, 0}, {0, -1}, {-1, 0}, {1, 1}, {-1, 1}, {1, -1}, {-1, -1}}
+
+// labelrings is the number of distances tried from a point
+const labelrings = 5
+
+// Place returns the labels in their places, in order; the first labels are placed first,
+// so the most important should lead.
+func (l Labeler) Place(labels []DataLabel) []PlacedLabel {
+	t := l.Text.defaults()
+	offset := l.Offset
+	if offset == 0 {
+		offset = 3
+	}
+	h := t.FontSize
+	placed := make([]PlacedLabel, 0, len(labels))
+	free := func(bx, by, bw float64) bool {
+		for _, p := range placed {
+			if bx < p.BX+p.W && p.BX < bx+bw && by < p.BY+p.H && p.BY < by+h {
+				return false
+			}
+		}
+		for _, d := range labels {
+			if d.X > bx-1 && d.X < bx+bw+1 && d.Y > by-1 && d.Y < by+h+1 {
+				return false
+			}
+		}
+		return true
+	}
+	for _, d := range labels {
+		w := t.width(d.Text)
+		p := PlacedLabel{DataLabel: d, BX: d.X - w/2, BY: d.Y + offset, W: w, H: h}
+	search:
+		for ring := 0; ring < labelrings; ring++ {
+			dist := offset + float64(ring)*h*1.5
+			for _, dir := range labeldirs {
+				bx := d.X + dir[0]*dist - w/2 + dir[0]*w/2
+				by := d.Y + dir[1]*dist - h/2 + dir[1]*h/2
+				if free(bx, by, w) {
+					p.BX, p.BY, p.Moved = bx, by, ring > 0
+					break search
+				}
+			}
+		}
+		placed = append(placed, p)
+	}
+	return placed
+}
+
+// Draw places the labels and draws them, with leader lines to those moved away from their points
+func (l Labeler) Draw(doc *pdfgen.PDFDoc, labels []DataLabel) {
+	t := l.Text.defaults()
+	leader := l.Leader
+	if leader == "" {
+		leader = "muted"
+	}
+	for _, p := range l.Place(labels) {
+		if p.Moved {
+			// to the nearest point of the label's box
+			lx := math.Max(p.BX, math.Min(p.X, p.BX+p.W))
+			ly := math.Max(p.BY, math.Min(p.Y, p.BY+p.H))
+			doc.Line(p.X, p.Y, lx, ly, 0.5, leader)
+		}
+		t.text(doc, p.BX, p.BY+p.H*0.2, p.Text)
+	}
+}
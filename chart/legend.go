@@ -0,0 +1,115 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// LegendKey is the symbol of a legend entry.
+type LegendKey int
+
+const (
+	// SwatchKey is a filled square, for bars and areas.
+	SwatchKey LegendKey = iota
+	// LineKey is a short line, with the entry's marker if it is marked, for lines.
+	LineKey
+	// MarkerKey is the entry's marker, for points.
+	MarkerKey
+)
+
+// LegendEntry is an entry of a legend: a key in a color, and a name.
+type LegendEntry struct {
+	Name   string
+	Color  string
+	Key    LegendKey
+	Marker pdfgen.Marker // marker of a MarkerKey, or of a marked LineKey
+	Marked bool          // mark a LineKey with the marker
+}
+
+// Legend is a row of entries, wrapping onto more rows as needed,
+// for charts built from the package's charts or from primitives.
+type Legend struct {
+	Entries []LegendEntry
+	Text    TextStyle
+}
+
+// SeriesEntries returns legend entries, with keys of the kind, for the named series
+func SeriesEntries(doc *pdfgen.PDFDoc, series []Series, key LegendKey) []LegendEntry {
+	var entries []LegendEntry
+	for i, s := range series {
+		if s.Name != "" {
+			entries = append(entries, LegendEntry{Name: s.Name, Color: s.color(doc, i), Key: key})
+		}
+	}
+	return entries
+}
+
+// keywidth returns the width of an entry's key
+func (l Legend) keywidth(e LegendEntry) float64 {
+	if e.Key == LineKey {
+		return l.Text.FontSize * 2
+	}
+	return l.Text.FontSize
+}
+
+// rows returns the entries laid out in rows no wider than width
+func (l Legend) rows(width float64) [][]LegendEntry {
+	var rows [][]LegendEntry
+	var row []LegendEntry
+	rw := 0.0
+	for _, e := range l.Entries {
+		ew := l.keywidth(e) + 4 + l.Text.width(e.Name) + l.Text.FontSize*1.5
+		if len(row) > 0 && rw+ew > width {
+			rows = append(rows, row)
+			row, rw = nil, 0
+		}
+		row, rw = append(row, e), rw+ew
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Height returns the height of the legend set in the width
+func (l Legend) Height(width float64) float64 {
+	l.Text = l.Text.defaults()
+	return float64(len(l.rows(width))) * l.Text.FontSize * 1.5
+}
+
+// Draw draws the legend set in the width, with its top left at (x,y)
+func (l Legend) Draw(doc *pdfgen.PDFDoc, x, y, width float64) {
+	l.Text = l.Text.defaults()
+	t := l.Text
+	lead := t.FontSize * 1.5
+	for _, row := range l.rows(width) {
+		ex := x
+		cy := y - lead/2
+		for _, e := range row {
+			kw := l.keywidth(e)
+			switch e.Key {
+			case LineKey:
+				doc.Line(ex, cy, ex+kw, cy, math.Max(1, t.FontSize/6), e.Color)
+				if e.Marked {
+					doc.Markers([]float64{ex + kw/2}, []float64{cy}, e.Marker, t.FontSize*0.6, e.Color)
+				}
+			case MarkerKey:
+				doc.Markers([]float64{ex + kw/2}, []float64{cy}, e.Marker, kw*0.8, e.Color)
+			default:
+				doc.Rect(ex, cy-kw/2, kw, kw, e.Color)
+			}
+			ex += kw + 4
+			t.text(doc, ex, cy-t.FontSize/3, e.Name)
+			ex += t.width(e.Name) + t.FontSize*1.5
+		}
+		y -= lead
+	}
+}
+
+// above draws the legend across the top of the w by h rectangle with its lower left at (x,y),
+// and returns the height left below it
+func (l Legend) above(doc *pdfgen.PDFDoc, x, y, w, h float64) float64 {
+	l.Draw(doc, x, y+h, w)
+	return h - l.Height(w) - 4
+}
@@ -0,0 +1,135 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// LineChart is a line chart of one or more series over shared x values.
+type LineChart struct {
+	X           []float64   // x values of the series' points; if empty, 1, 2, 3...
+	Series      []Series    // the y values of each line
+	XAxis       pdfgen.Axis // x axis; the range is from the data if Min and Max are equal
+	YAxis       pdfgen.Axis // y axis; the range is from the data if Min and Max are equal
+	StrokeWidth float64     // width of the lines, default 1.5
+	Smooth      bool        // draw smooth splines through the points, instead of straight segments
+	Markers     bool        // mark the points, with a different marker for each series
+	MarkerSize  float64     // size of the markers, default 5
+	Legend      bool        // show a legend of the named series above the plot
+	Text        TextStyle   // style of the legend
+}
+
+// xvalues returns the x values of n points: x, or if it is short, 1 to n
+func xvalues(x []float64, n int) []float64 {
+	if len(x) >= n {
+		return x
+	}
+	x = make([]float64, n)
+	for i := range x {
+		x[i] = float64(i + 1)
+	}
+	return x
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c LineChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := categories(nil, c.Series)
+	if n == 0 {
+		return
+	}
+	xs := xvalues(c.X, n)
+	xmin, xmax := extent([]Series{{Values: xs[:n]}})
+	ymin, ymax := extent(c.Series)
+	xa := valueaxis(c.XAxis, xmin, xmax)
+	ya := valueaxis(c.YAxis, ymin, ymax)
+	sw := c.StrokeWidth
+	if sw == 0 {
+		sw = 1.5
+	}
+	ms := c.MarkerSize
+	if ms == 0 {
+		ms = 5
+	}
+
+	p := plotarea(x, y, w, h, xa, ya)
+	if c.Legend {
+		l := Legend{Entries: SeriesEntries(doc, c.Series, LineKey), Text: c.Text}
+		if c.Markers {
+			for i := range l.Entries {
+				l.Entries[i].Marker, l.Entries[i].Marked = pdfgen.Marker(i%7), true
+			}
+		}
+		p = plotarea(x, y, w, l.above(doc, x, y, w, h), xa, ya)
+	}
+	if p.w <= 0 || p.h <= 0 {
+		return
+	}
+	p.axes(doc, &xa, &ya)
+	for i, s := range c.Series {
+		color := s.color(doc, i)
+		for _, run := range p.runs(xs, s.Values, xa, ya) {
+			if c.Smooth && len(run[0]) > 2 {
+				doc.Spline(run[0], run[1], sw, color)
+			} else {
+				doc.Polyline(run[0], run[1], sw, color)
+			}
+			if c.Markers {
+				doc.Markers(run[0], run[1], pdfgen.Marker(i%7), ms, color)
+			}
+		}
+	}
+}
+
+// plot is the area of a chart within its axes and their labels
+type plot struct {
+	x, y, w, h float64
+}
+
+// plotarea returns the plot within the w by h rectangle at (x,y), leaving room for the axes' labels
+func plotarea(x, y, w, h float64, xa, ya pdfgen.Axis) plot {
+	left := labelwidth(ya) + ticksize(ya) + 4
+	bottom := ticksize(xa) + axisfont(xa).FontSize*1.5
+	// room for half the last x label beyond the plot
+	right := axisfont(xa).width(xa.Label(xa.Max)) / 2
+	top := axisfont(ya).FontSize / 2
+	return plot{x + left, y + bottom, w - left - right, h - bottom - top}
+}
+
+// axes draws the x and y axes along the bottom and left of the plot, with gridlines across it
+func (p plot) axes(doc *pdfgen.PDFDoc, xa, ya *pdfgen.Axis) {
+	if xa.GridLength == 0 {
+		xa.GridLength = p.h
+	}
+	if ya.GridLength == 0 {
+		ya.GridLength = p.w
+	}
+	doc.XAxis(p.x, p.y, p.w, *xa)
+	doc.YAxis(p.x, p.y, p.h, *ya)
+}
+
+// point returns the position of the data point (vx,vy)
+func (p plot) point(vx, vy float64, xa, ya pdfgen.Axis) (float64, float64) {
+	return p.x + pdfgen.MapRange(vx, xa.Min, xa.Max, 0, p.w), p.y + pdfgen.MapRange(vy, ya.Min, ya.Max, 0, p.h)
+}
+
+// runs returns the positions of the points, in runs broken at missing values
+func (p plot) runs(xs, ys []float64, xa, ya pdfgen.Axis) [][2][]float64 {
+	var runs [][2][]float64
+	var run [2][]float64
+	for i := range ys {
+		if i >= len(xs) || math.IsNaN(ys[i]) {
+			if len(run[0]) > 0 {
+				runs = append(runs, run)
+			}
+			run = [2][]float64{}
+			continue
+		}
+		px, py := p.point(xs[i], ys[i], xa, ya)
+		run[0], run[1] = append(run[0], px), append(run[1], py)
+	}
+	if len(run[0]) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
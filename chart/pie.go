@@ -0,0 +1,108 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// PieChart is a pie chart, or with a hole, a donut chart: slices proportional to the values,
+// clockwise from the top.
+type PieChart struct {
+	Labels  []string  // names of the slices, shown in the legend
+	Values  []float64 // sizes of the slices; negative values are left out
+	Colors  []string  // colors of the slices; if empty, the theme's series colors
+	Hole    float64   // radius of the hole as a fraction of the radius, for a donut chart
+	Explode []float64 // distance each slice is moved out from the center
+	Outline string    // color of the lines between slices; none if empty
+	Percent bool      // label each slice, outside the pie, with its percentage of the total
+	Format  string    // format of the percentages, default "%.0f%%"
+	Legend  bool      // show a legend of the slices above the pie
+	Text    TextStyle // style of the labels and legend
+}
+
+// color returns the color of slice i
+func (c PieChart) color(doc *pdfgen.PDFDoc, i int) string {
+	if i < len(c.Colors) && c.Colors[i] != "" {
+		return c.Colors[i]
+	}
+	return doc.Theme().SeriesColor(i + 1)
+}
+
+// explode returns the distance slice i is moved out
+func (c PieChart) explode(i int) float64 {
+	if i < len(c.Explode) {
+		return c.Explode[i]
+	}
+	return 0
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c PieChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	total := 0.0
+	for _, v := range c.Values {
+		if v > 0 {
+			total += v
+		}
+	}
+	if total == 0 {
+		return
+	}
+	t := c.Text.defaults()
+	format := c.Format
+	if format == "" {
+		format = "%.0f%%"
+	}
+	if c.Legend {
+		l := Legend{Text: t}
+		for i, name := range c.Labels {
+			if i < len(c.Values) && c.Values[i] > 0 {
+				l.Entries = append(l.Entries, LegendEntry{Name: name, Color: c.color(doc, i)})
+			}
+		}
+		h = l.above(doc, x, y, w, h)
+	}
+
+	// the pie, centered, inside its exploded slices and labels
+	margin := 0.0
+	for i := range c.Values {
+		margin = math.Max(margin, c.explode(i))
+	}
+	if c.Percent {
+		margin += t.width(valueformat(format, 100)) + 6
+	}
+	r := math.Min(w, h)/2 - margin
+	if r <= 0 {
+		return
+	}
+	cx, cy := x+w/2, y+h/2
+	angle := 90.0
+	for i, v := range c.Values {
+		if v <= 0 {
+			continue
+		}
+		sweep := v / total * 360
+		start, end := angle-sweep, angle
+		mid := (start + end) * math.Pi / 360
+		dx, dy := math.Cos(mid)*c.explode(i), math.Sin(mid)*c.explode(i)
+		color := c.color(doc, i)
+		if c.Hole > 0 {
+			doc.RingSegment(cx+dx, cy+dy, r*c.Hole, r, start, end, color)
+			if c.Outline != "" {
+				doc.StrokeRingSegment(cx+dx, cy+dy, r*c.Hole, r, start, end, 1, c.Outline)
+			}
+		} else {
+			doc.Wedge(cx+dx, cy+dy, r, start, end, color)
+			if c.Outline != "" {
+				doc.StrokeWedge(cx+dx, cy+dy, r, start, end, 1, c.Outline)
+			}
+		}
+		if c.Percent {
+			l := valueformat(format, v/total*100)
+			lr := r + c.explode(i) + 4
+			lx, ly := cx+math.Cos(mid)*lr, cy+math.Sin(mid)*lr
+			t.radial(doc, lx, ly, mid, l)
+		}
+		angle = start
+	}
+}
@@ -0,0 +1,93 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// RadarChart is a radar (spider) chart: axes radiating from the center, one for each variable,
+// clockwise from the top, and a polygon joining the values of each series on them.
+type RadarChart struct {
+	Axes      []string    // names of the variables, at the ends of the axes
+	Series    []Series    // the values of each series, one for each axis
+	Scale     pdfgen.Axis // range and step of the gridlines; from zero to the greatest value if Min and Max are equal
+	GridColor string      // color of the axes and gridlines, default lightgray
+	Opacity   float64     // opacity of the fills, as a percentage, default 25; the outlines are opaque
+	Legend    bool        // show a legend of the named series above the chart
+	Text      TextStyle   // style of the axis names and legend
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c RadarChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := categories(c.Axes, c.Series)
+	if n < 3 {
+		return
+	}
+	_, max := extent(c.Series)
+	a := valueaxis(c.Scale, 0, math.Max(max, 0))
+	t := c.Text.defaults()
+	grid := c.GridColor
+	if grid == "" {
+		grid = "lightgray"
+	}
+	opacity := c.Opacity
+	if opacity == 0 {
+		opacity = 25
+	}
+	if c.Legend {
+		l := Legend{Entries: SeriesEntries(doc, c.Series, SwatchKey), Text: t}
+		h = l.above(doc, x, y, w, h)
+	}
+
+	// the chart, centered, inside the axis names
+	names := 0.0
+	for _, s := range c.Axes {
+		names = math.Max(names, t.width(s))
+	}
+	r := math.Min(w/2-names-6, h/2-t.FontSize*1.5)
+	if r <= 0 {
+		return
+	}
+	cx, cy := x+w/2, y+h/2
+	angle := func(i int) float64 { return math.Pi/2 - 2*math.Pi*float64(i)/float64(n) }
+	point := func(i int, v float64) (float64, float64) {
+		d := pdfgen.MapRange(math.Max(a.Min, math.Min(a.Max, v)), a.Min, a.Max, 0, r)
+		return cx + d*math.Cos(angle(i)), cy + d*math.Sin(angle(i))
+	}
+	polygon := func(v func(i int) float64) ([]float64, []float64) {
+		px, py := make([]float64, n), make([]float64, n)
+		for i := range px {
+			px[i], py[i] = point(i, v(i))
+		}
+		return px, py
+	}
+
+	af := axisfont(a)
+	for _, v := range pdfgen.Ticks(a.Min, a.Max, a.Step) {
+		if v == a.Min {
+			continue
+		}
+		px, py := polygon(func(int) float64 { return v })
+		doc.StrokePolygon(px, py, 0.5, grid)
+		af.text(doc, cx+2, py[0]+1, a.Label(v))
+	}
+	for i := 0; i < n; i++ {
+		ex, ey := point(i, a.Max)
+		doc.Line(cx, cy, ex, ey, 0.5, grid)
+		if i >= len(c.Axes) {
+			continue
+		}
+		lx, ly := cx+(r+6)*math.Cos(angle(i)), cy+(r+6)*math.Sin(angle(i))
+		t.radial(doc, lx, ly, angle(i), c.Axes[i])
+	}
+	for s, series := range c.Series {
+		color := series.color(doc, s)
+		px, py := polygon(func(i int) float64 {
+			v, _ := series.value(i)
+			return v
+		})
+		doc.Polygon(px, py, color+"/"+valueformat("", opacity))
+		doc.StrokePolygon(px, py, 1.5, color)
+	}
+}
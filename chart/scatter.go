@@ -0,0 +1,144 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Points is a named set of (x,y) points, optionally sized by a third variable.
+type Points struct {
+	Name   string
+	X, Y   []float64
+	Size   []float64     // values mapped to the areas of the markers, for a bubble chart; if empty, all one size
+	Labels []string      // labels of the points, placed beside them without overlap
+	Color  string        // color of the points; if empty, the theme's series color, by position
+	Marker pdfgen.Marker // marker of the points
+}
+
+// ScatterChart plots sets of points on x and y axes.
+type ScatterChart struct {
+	Series     []Points
+	XAxis      pdfgen.Axis // x axis; the range is from the data if Min and Max are equal
+	YAxis      pdfgen.Axis // y axis; the range is from the data if Min and Max are equal
+	MarkerSize float64     // size of the markers, default 5; the size of the largest bubble, default 30
+	Opacity    float64     // opacity of the markers, as a percentage, default 100 (70 for bubbles)
+	Trend      bool        // draw a least squares regression line through each set of points
+	Legend     bool        // show a legend of the named sets above the plot
+	Text       TextStyle   // style of the point labels and legend
+}
+
+// bubbles reports whether any points are sized
+func (c ScatterChart) bubbles() bool {
+	for _, s := range c.Series {
+		if len(s.Size) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Draw draws the chart in the w by h rectangle with its lower left at (x,y)
+func (c ScatterChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	if len(c.Series) == 0 {
+		return
+	}
+	xs, ys, sizes := make([]Series, len(c.Series)), make([]Series, len(c.Series)), make([]Series, len(c.Series))
+	for i, s := range c.Series {
+		xs[i], ys[i], sizes[i] = Series{Values: s.X}, Series{Values: s.Y}, Series{Values: s.Size}
+	}
+	xmin, xmax := extent(xs)
+	ymin, ymax := extent(ys)
+	xa := valueaxis(c.XAxis, xmin, xmax)
+	ya := valueaxis(c.YAxis, ymin, ymax)
+	bubbles := c.bubbles()
+	_, smax := extent(sizes)
+	ms, opacity := c.MarkerSize, c.Opacity
+	if ms == 0 {
+		ms = 5
+		if bubbles {
+			ms = 30
+		}
+	}
+	if opacity == 0 {
+		opacity = 100
+		if bubbles {
+			opacity = 70
+		}
+	}
+
+	p := plotarea(x, y, w, h, xa, ya)
+	if c.Legend {
+		l := Legend{Text: c.Text}
+		for i, s := range c.Series {
+			if s.Name != "" {
+				l.Entries = append(l.Entries, LegendEntry{Name: s.Name, Color: c.color(doc, i), Key: MarkerKey, Marker: s.Marker})
+			}
+		}
+		p = plotarea(x, y, w, l.above(doc, x, y, w, h), xa, ya)
+	}
+	if p.w <= 0 || p.h <= 0 {
+		return
+	}
+	p.axes(doc, &xa, &ya)
+	var labels []DataLabel
+	for i, s := range c.Series {
+		color := c.color(doc, i)
+		if opacity < 100 {
+			color += "/" + valueformat("", opacity)
+		}
+		for j := range s.X {
+			if j >= len(s.Y) || math.IsNaN(s.X[j]) || math.IsNaN(s.Y[j]) {
+				continue
+			}
+			size := ms
+			if j < len(s.Size) && smax > 0 {
+				// areas proportional to the values
+				size = ms * math.Sqrt(math.Max(s.Size[j], 0)/smax)
+			}
+			px, py := p.point(s.X[j], s.Y[j], xa, ya)
+			doc.Markers([]float64{px}, []float64{py}, s.Marker, size, color)
+			if j < len(s.Labels) && s.Labels[j] != "" {
+				labels = append(labels, DataLabel{X: px, Y: py, Text: s.Labels[j]})
+			}
+		}
+		if c.Trend {
+			if slope, intercept, ok := regression(s.X, s.Y); ok {
+				x1, y1 := p.point(xa.Min, intercept+slope*xa.Min, xa, ya)
+				x2, y2 := p.point(xa.Max, intercept+slope*xa.Max, xa, ya)
+				doc.Push()
+				doc.ClipRect(p.x, p.y, p.w, p.h)
+				doc.Line(x1, y1, x2, y2, 1, c.color(doc, i))
+				doc.Pop()
+			}
+		}
+	}
+	Labeler{Text: c.Text, Offset: ms/2 + 2}.Draw(doc, labels)
+}
+
+// color returns the color of set i
+func (c ScatterChart) color(doc *pdfgen.PDFDoc, i int) string {
+	return Series{Color: c.Series[i].Color}.color(doc, i)
+}
+
+// regression returns the slope and intercept of the least squares line through the points,
+// and whether there is one
+func regression(x, y []float64) (float64, float64, bool) {
+	var n, sx, sy, sxx, sxy float64
+	for i := range x {
+		if i >= len(y) || math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			continue
+		}
+		n++
+		sx += x[i]
+		sy += y[i]
+		sxx += x[i] * x[i]
+		sxy += x[i] * y[i]
+	}
+	d := n*sxx - sx*sx
+	if n < 2 || d == 0 {
+		return 0, 0, false
+	}
+	slope := (n*sxy - sx*sy) / d
+	return slope, (sy - slope*sx) / n, true
+}
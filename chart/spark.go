@@ -0,0 +1,116 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Sparkline is a tiny line or bar chart, without axes, to be set among text:
+// a trend glyph beside a number, or in a table cell.
+type Sparkline struct {
+	Values        []float64
+	Bars          bool    // draw bars (a sparkbar) instead of a line
+	Min, Max      float64 // range of the values; from the data, including zero for bars, if equal
+	Color         string  // color of the line or bars, default foreground
+	NegativeColor string  // color of bars below zero, default the color
+	StrokeWidth   float64 // width of the line, default 0.75
+	LastColor     string  // color of a dot at the last value; none if empty
+	MinColor      string  // color of a dot at the least value; none if empty
+	MaxColor      string  // color of a dot at the greatest value; none if empty
+}
+
+// Inline draws the sparkline sized to a line of text of the font size, with its bottom on
+// the baseline at (x,y) and four ems wide, and returns its width, so that text may follow
+func (s Sparkline) Inline(doc *pdfgen.PDFDoc, x, y, size float64) float64 {
+	w := size * 4
+	s.Draw(doc, x, y, w, size*0.75)
+	return w
+}
+
+// Draw draws the sparkline in the w by h rectangle with its lower left at (x,y)
+func (s Sparkline) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	n := len(s.Values)
+	if n == 0 {
+		return
+	}
+	min, max := s.Min, s.Max
+	if min == max {
+		min, max = extent([]Series{{Values: s.Values}})
+		if s.Bars {
+			min, max = math.Min(min, 0), math.Max(max, 0)
+		}
+	}
+	if min == max {
+		min, max = min-1, max+1
+	}
+	color := s.Color
+	if color == "" {
+		color = "foreground"
+	}
+	vy := func(v float64) float64 {
+		return y + pdfgen.MapRange(math.Max(min, math.Min(max, v)), min, max, 0, h)
+	}
+
+	if s.Bars {
+		band := w / float64(n)
+		bw := band * 0.8
+		base := vy(0)
+		for i, v := range s.Values {
+			if math.IsNaN(v) {
+				continue
+			}
+			c := color
+			if v < 0 && s.NegativeColor != "" {
+				c = s.NegativeColor
+			}
+			top := vy(v)
+			doc.Rect(x+float64(i)*band+(band-bw)/2, math.Min(base, top), bw, math.Max(math.Abs(top-base), 0.25), c)
+		}
+		return
+	}
+
+	sw := s.StrokeWidth
+	if sw == 0 {
+		sw = 0.75
+	}
+	step := 0.0
+	if n > 1 {
+		step = w / float64(n-1)
+	}
+	p := plot{x: x, y: y, w: w, h: h}
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	xa := pdfgen.Axis{Min: 0, Max: float64(n - 1)}
+	if n == 1 {
+		xa.Max = 1
+	}
+	ya := pdfgen.Axis{Min: min, Max: max}
+	for _, run := range p.runs(xs, s.Values, xa, ya) {
+		doc.Polyline(run[0], run[1], sw, color)
+	}
+	dot := func(i int, c string) {
+		if c != "" && i >= 0 {
+			doc.Circle(x+float64(i)*step, vy(s.Values[i]), sw*1.5, c)
+		}
+	}
+	lo, hi := -1, -1
+	for i, v := range s.Values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if lo < 0 || v < s.Values[lo] {
+			lo = i
+		}
+		if hi < 0 || v > s.Values[hi] {
+			hi = i
+		}
+	}
+	dot(lo, s.MinColor)
+	dot(hi, s.MaxColor)
+	if !math.IsNaN(s.Values[n-1]) {
+		dot(n-1, s.LastColor)
+	}
+}
@@ -0,0 +1,64 @@
+package chart
+
+import (
+	"math"
+	"sort"
+)
+
+// sorted returns the values, without missing ones, in increasing order
+func sorted(values []float64) []float64 {
+	s := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			s = append(s, v)
+		}
+	}
+	sort.Float64s(s)
+	return s
+}
+
+// quantile returns the q quantile (from 0 to 1) of the sorted values, interpolating between them
+func quantile(s []float64, q float64) float64 {
+	switch len(s) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return s[0]
+	}
+	pos := q * float64(len(s)-1)
+	i := int(pos)
+	if i >= len(s)-1 {
+		return s[len(s)-1]
+	}
+	return s[i] + (pos-float64(i))*(s[i+1]-s[i])
+}
+
+// BoxStats summarizes a set of values for a box plot: the quartiles, the ends of the whiskers
+// (the most extreme values within 1.5 times the interquartile range of the box), and the
+// outliers beyond them.
+type BoxStats struct {
+	Low, Q1, Median, Q3, High float64
+	Outliers                  []float64
+}
+
+// Summarize returns the box plot summary of the values
+func Summarize(values []float64) BoxStats {
+	s := sorted(values)
+	if len(s) == 0 {
+		return BoxStats{Low: math.NaN(), Q1: math.NaN(), Median: math.NaN(), Q3: math.NaN(), High: math.NaN()}
+	}
+	b := BoxStats{Q1: quantile(s, 0.25), Median: quantile(s, 0.5), Q3: quantile(s, 0.75)}
+	fence := 1.5 * (b.Q3 - b.Q1)
+	b.Low, b.High = b.Q1, b.Q3
+	for _, v := range s {
+		switch {
+		case v < b.Q1-fence || v > b.Q3+fence:
+			b.Outliers = append(b.Outliers, v)
+		case v < b.Low:
+			b.Low = v
+		case v > b.High:
+			b.High = v
+		}
+	}
+	return b
+}
@@ -0,0 +1,154 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Node is a node of a tree chart.
+type Node struct {
+	Label    string
+	Children []*Node
+	Color    string // fill color of the node's box, over the chart's
+}
+
+// Connector is the style of the links between nodes of a tree chart.
+type Connector int
+
+const (
+	// Orthogonal links run down from the parent, across, and down to each child.
+	Orthogonal Connector = iota
+	// Straight links run directly from the parent to each child.
+	Straight
+)
+
+// TreeChart draws a hierarchy, such as an organization or a decision tree, as boxes in layers
+// from the root at the top, each parent centered over its children, linked by connectors.
+type TreeChart struct {
+	Root       *Node
+	BoxWidth   float64   // greatest width of the boxes, default 90; they narrow to fit the chart
+	SiblingGap float64   // space between adjacent boxes, default 10
+	Connector  Connector // style of the links
+	Fill       string    // fill color of the boxes, default background (white on an unpainted page)
+	Stroke     string    // color of the box outlines and links, default foreground
+	Text       TextStyle // style of the labels, which wrap within the boxes
+}
+
+// treeplace is a node in its place: its column (in units of box and gap), and level
+type treeplace struct {
+	node   *Node
+	col    float64
+	level  int
+	parent int // index of the parent's place, -1 for the root
+}
+
+// treelayout places the nodes of the tree, with each leaf in the next column and each parent
+// centered over its children, returning the places, the number of columns, and of levels
+func treelayout(root *Node) ([]treeplace, int, int) {
+	var places []treeplace
+	leaves, levels := 0, 0
+	var place func(n *Node, level, parent int) float64
+	place = func(n *Node, level, parent int) float64 {
+		i := len(places)
+		places = append(places, treeplace{node: n, level: level, parent: parent})
+		if level+1 > levels {
+			levels = level + 1
+		}
+		if len(n.Children) == 0 {
+			places[i].col = float64(leaves)
+			leaves++
+			return places[i].col
+		}
+		first, last := 0.0, 0.0
+		for k, child := range n.Children {
+			c := place(child, level+1, i)
+			if k == 0 {
+				first = c
+			}
+			last = c
+		}
+		places[i].col = (first + last) / 2
+		return places[i].col
+	}
+	place(root, 0, -1)
+	return places, leaves, levels
+}
+
+// Draw draws the tree in the w by h rectangle with its lower left at (x,y)
+func (c TreeChart) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	if c.Root == nil {
+		return
+	}
+	t := c.Text.defaults()
+	bw, gap := c.BoxWidth, c.SiblingGap
+	if bw == 0 {
+		bw = 90
+	}
+	if gap == 0 {
+		gap = 10
+	}
+	fill, stroke := c.Fill, c.Stroke
+	if fill == "" {
+		fill = "background"
+	}
+	if stroke == "" {
+		stroke = "foreground"
+	}
+	places, cols, levels := treelayout(c.Root)
+	if fit := (w - float64(cols-1)*gap) / float64(cols); fit < bw {
+		bw = fit
+	}
+	if bw <= 0 {
+		return
+	}
+
+	// boxes all one height, to fit the longest label
+	pad := t.FontSize / 2
+	lead := t.FontSize * 1.2
+	lines := make([][]string, len(places))
+	nlines := 1
+	for i, p := range places {
+		lines[i] = pdfgen.WrapText(p.node.Label, t.Font, t.FontSize, bw-2*pad)
+		nlines = int(math.Max(float64(nlines), float64(len(lines[i]))))
+	}
+	bh := float64(nlines)*lead + 2*pad
+	levelgap := bh
+	if levels > 1 {
+		levelgap = math.Min(bh*1.5, (h-float64(levels)*bh)/float64(levels-1))
+	}
+	if levelgap < 0 {
+		return
+	}
+	left := x + (w-float64(cols)*bw-float64(cols-1)*gap)/2
+	center := func(p treeplace) (float64, float64) {
+		return left + p.col*(bw+gap) + bw/2, y + h - float64(p.level)*(bh+levelgap) - bh/2
+	}
+
+	for _, p := range places {
+		if p.parent < 0 {
+			continue
+		}
+		px, py := center(places[p.parent])
+		cx, cy := center(p)
+		if c.Connector == Straight {
+			doc.Line(px, py-bh/2, cx, cy+bh/2, 0.75, stroke)
+			continue
+		}
+		my := py - bh/2 - levelgap/2
+		doc.Polyline([]float64{px, px, cx, cx}, []float64{py - bh/2, my, my, cy + bh/2}, 0.75, stroke)
+	}
+	for i, p := range places {
+		cx, cy := center(p)
+		bf := fill
+		if p.node.Color != "" {
+			bf = p.node.Color
+		}
+		doc.FillStrokeRect(cx-bw/2, cy-bh/2, bw, bh, 0.75, bf, stroke)
+		ly := cy + float64(len(lines[i])-1)*lead/2 - t.FontSize/3
+		for _, l := range lines[i] {
+			t.ctext(doc, cx, ly, l)
+			ly -= lead
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package pdfgen
+
+// Clipping regions intersect with the current clip, and remain in effect
+// until the page ends; scope them with Push and Pop.
+
+// ClipRect confines subsequent drawing to the rectangle with its lower left at (x,y)
+func (p *PDFDoc) ClipRect(x, y, w, h float64) {
+	p.rectpath(x, y, w, h)
+	p.clip()
+}
+
+// ClipPath confines subsequent drawing to the current path, built with MoveTo, LineTo, etc.
+func (p *PDFDoc) ClipPath() {
+	p.clip()
+}
+
+// ClipPolygon confines subsequent drawing to the polygon
+func (p *PDFDoc) ClipPolygon(x []float64, y []float64) {
+	if p.polygonpath(x, y) {
+		p.clip()
+	}
+}
+
+// ClipCircle confines subsequent drawing to the circle centered at (x,y) with radius r
+func (p *PDFDoc) ClipCircle(x, y, r float64) {
+	p.ClipEllipse(x, y, r, r)
+}
+
+// ClipEllipse confines subsequent drawing to the ellipse centered at (x,y) with radii w and h
+func (p *PDFDoc) ClipEllipse(x, y, w, h float64) {
+	p.ellipsepath(x, y, w, h)
+	p.clip()
+}
+
+// clip intersects the clipping region with the current path, using the fill rule, and ends the path
+func (p *PDFDoc) clip() {
+	op := "W"
+	if p.fillrule == EvenOdd {
+		op = "W*"
+	}
+	p.endpath(op + " n")
+}
@@ -0,0 +1,94 @@
+// csvtable renders CSV or TSV files as tables in a PDF document
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ajstarks/pdfgen"
+	"github.com/ajstarks/pdfgen/table"
+)
+
+func main() {
+	var (
+		tsv        = flag.Bool("tsv", false, "read tab separated values")
+		header     = flag.Bool("header", true, "the first record is a header")
+		pagewidth  = flag.Float64("pagewidth", 612, "page width")
+		pageheight = flag.Float64("pageheight", 792, "page height")
+		margin     = flag.Float64("margin", 36, "page margin")
+		font       = flag.String("font", "sans", "font")
+		fontsize   = flag.Float64("fontsize", 10, "font size")
+		zebra      = flag.String("zebra", "whitesmoke", "background of alternate rows")
+		border     = flag.String("border", "gray", "cell border color")
+		output     = flag.String("o", "", "output file (default standard output)")
+	)
+	flag.Parse()
+
+	opts := table.CSVOptions{
+		Width:  *pagewidth - 2**margin,
+		Header: *header,
+		Style:  table.Style{Font: *font, FontSize: *fontsize, Border: *border},
+		Zebra:  *zebra,
+	}
+	if *tsv {
+		opts.Comma = '\t'
+	}
+	var tables []*table.Table
+	if flag.NArg() == 0 {
+		t, err := table.FromCSV(os.Stdin, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "csvtable: %v\n", err)
+			os.Exit(1)
+		}
+		tables = append(tables, t)
+	}
+	for _, name := range flag.Args() {
+		t, err := readtable(name, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "csvtable: %v\n", err)
+			os.Exit(1)
+		}
+		tables = append(tables, t)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "csvtable: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	// each table begins a new page
+	top, bottom := *pageheight-*margin, *margin
+	npages := 0
+	for _, t := range tables {
+		npages += t.Pages(top, top, bottom)
+	}
+	doc := pdfgen.NewDoc(w, *pagewidth, *pageheight)
+	doc.Init(npages)
+	for _, t := range tables {
+		doc.NewPage(doc.Page() + 1)
+		t.DrawPages(doc, *margin, top, top, bottom)
+		doc.EndPage()
+	}
+	if err := doc.EndDoc(); err != nil {
+		fmt.Fprintf(os.Stderr, "csvtable: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readtable reads a table from the named file
+func readtable(name string, opts table.CSVOptions) (*table.Table, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return table.FromCSV(r, opts)
+}
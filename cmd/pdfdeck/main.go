@@ -0,0 +1,71 @@
+// pdfdeck renders deck markup files as PDF slides.
+// Each file is written beside it, with the extension .pdf; standard input is
+// rendered to standard output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ajstarks/pdfgen/deck"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, for a single input (default the input name, with .pdf)")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		if err := render(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "pdfdeck: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *output != "" && flag.NArg() > 1 {
+		fmt.Fprintln(os.Stderr, "pdfdeck: -o needs a single input")
+		os.Exit(2)
+	}
+	status := 0
+	for _, name := range flag.Args() {
+		out := *output
+		if out == "" {
+			out = strings.TrimSuffix(name, filepath.Ext(name)) + ".pdf"
+		}
+		if err := renderfile(name, out); err != nil {
+			fmt.Fprintf(os.Stderr, "pdfdeck: %s: %v\n", name, err)
+			status = 1
+		}
+	}
+	os.Exit(status)
+}
+
+// render renders the deck read from r to w
+func render(r io.Reader, w io.Writer) error {
+	d, err := deck.Read(r)
+	if err != nil {
+		return err
+	}
+	return d.Render(w)
+}
+
+// renderfile renders the named deck file to the named PDF file
+func renderfile(name, out string) error {
+	r, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := render(r, w); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,69 @@
+// pdfgen makes a PDF document from a JSON scene description: the page size, and pages of
+// text, shapes, paths, images, and charts. For example:
+//
+//	{"pages": [{"items": [
+//		{"type": "text", "x": 72, "y": 720, "text": "Hello, world", "size": 24},
+//		{"type": "rect", "x": 72, "y": 600, "w": 200, "h": 80, "fill": "steelblue", "radius": 8},
+//		{"type": "chart", "chart": "bar", "x": 72, "y": 300, "w": 400, "h": 250,
+//			"categories": ["a", "b", "c"], "series": [{"name": "n", "values": [3, 5, 2]}]}
+//	]}]}
+//
+// The scene is read from the named file, or standard input, and the document written to
+// standard output, or the file named with -o.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+func main() {
+	output := flag.String("o", "", "output file (default standard output)")
+	flag.Parse()
+
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pdfgen: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+	var scene Scene
+	if err := json.NewDecoder(r).Decode(&scene); err != nil {
+		fmt.Fprintf(os.Stderr, "pdfgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if *output != "" {
+		var err error
+		if f, err = os.Create(*output); err != nil {
+			fmt.Fprintf(os.Stderr, "pdfgen: %v\n", err)
+			os.Exit(1)
+		}
+		w = f
+	}
+	width, height := scene.size()
+	err := scene.draw(pdfgen.NewDoc(w, width, height))
+	if f != nil {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(*output) // not leaving a partial document
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pdfgen: %v\n", err)
+		os.Exit(1)
+	}
+}
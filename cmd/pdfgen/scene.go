@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+	"github.com/ajstarks/pdfgen/chart"
+)
+
+// Scene is a document: the page size, and the pages.
+type Scene struct {
+	Width  float64 `json:"width"`  // default 612
+	Height float64 `json:"height"` // default 792
+	Pages  []Page  `json:"pages"`
+}
+
+// Page is a page's background color and items, drawn in order.
+type Page struct {
+	Background string `json:"background"`
+	Items      []Item `json:"items"`
+}
+
+// Item is something drawn on a page; its type selects which of the fields apply:
+//
+//	text      x, y, text, font, size, color, align (left, center, right), width (to wrap)
+//	rect      x, y, w, h, radius, and the style fields
+//	circle    x, y, r, and the style fields
+//	ellipse   x, y, w, h (radii), and the style fields
+//	line      x1, y1, x2, y2, and the style fields
+//	polyline  points (x, y, x, y...), and the style fields
+//	polygon   points, and the style fields
+//	path      d (SVG path data), and the style fields
+//	image     x, y, w, h, file
+//	chart     x, y, w, h, chart (bar, line, pie), categories, series, labels, values, legend
+//
+// The style fields are fill, stroke, strokewidth, opacity, and dash. Closed shapes without
+// a fill or stroke are filled with black; lines without a stroke are stroked with black.
+// Positions are in points, from the lower left of the page.
+type Item struct {
+	Type   string    `json:"type"`
+	X      float64   `json:"x"`
+	Y      float64   `json:"y"`
+	W      float64   `json:"w"`
+	H      float64   `json:"h"`
+	R      float64   `json:"r"`
+	X1     float64   `json:"x1"`
+	Y1     float64   `json:"y1"`
+	X2     float64   `json:"x2"`
+	Y2     float64   `json:"y2"`
+	Points []float64 `json:"points"`
+	D      string    `json:"d"`
+
+	Text  string  `json:"text"`
+	Font  string  `json:"font"`
+	Size  float64 `json:"size"`
+	Color string  `json:"color"`
+	Align string  `json:"align"`
+	Width float64 `json:"width"`
+
+	Fill        string    `json:"fill"`
+	Stroke      string    `json:"stroke"`
+	StrokeWidth float64   `json:"strokewidth"`
+	Opacity     float64   `json:"opacity"`
+	Dash        []float64 `json:"dash"`
+	Radius      float64   `json:"radius"`
+
+	File string `json:"file"`
+
+	Chart      string         `json:"chart"`
+	Categories []string       `json:"categories"`
+	Series     []chart.Series `json:"series"`
+	Labels     []string       `json:"labels"`
+	Values     []float64      `json:"values"`
+	Legend     bool           `json:"legend"`
+}
+
+// size returns the page size, with defaults
+func (s Scene) size() (float64, float64) {
+	w, h := s.Width, s.Height
+	if w <= 0 {
+		w = 612
+	}
+	if h <= 0 {
+		h = 792
+	}
+	return w, h
+}
+
+// style returns the item's style; filled black if closed and neither filled nor stroked,
+// or stroked black if open and not stroked
+func (it Item) style(closed bool) pdfgen.Style {
+	s := pdfgen.Style{Fill: it.Fill, Stroke: it.Stroke, StrokeWidth: it.StrokeWidth, Opacity: it.Opacity, Dash: it.Dash, Radius: it.Radius}
+	switch {
+	case closed && s.Fill == "" && s.Stroke == "":
+		s.Fill = "black"
+	case !closed && s.Stroke == "":
+		s.Stroke = "black"
+	}
+	return s
+}
+
+// points splits the item's points into x and y coordinates
+func (it Item) points() ([]float64, []float64, error) {
+	if len(it.Points)%2 != 0 || len(it.Points) < 4 {
+		return nil, nil, fmt.Errorf("%s: points must be two or more x, y pairs", it.Type)
+	}
+	x := make([]float64, 0, len(it.Points)/2)
+	y := make([]float64, 0, len(it.Points)/2)
+	for i := 0; i < len(it.Points); i += 2 {
+		x = append(x, it.Points[i])
+		y = append(y, it.Points[i+1])
+	}
+	return x, y, nil
+}
+
+// draw draws the scene to doc
+func (s Scene) draw(doc *pdfgen.PDFDoc) error {
+	w, h := s.size()
+	doc.Init(len(s.Pages))
+	for i, page := range s.Pages {
+		doc.NewPage(i + 1)
+		if page.Background != "" {
+			doc.Rect(0, 0, w, h, page.Background)
+		}
+		for j, it := range page.Items {
+			if err := it.draw(doc); err != nil {
+				return fmt.Errorf("page %d, item %d: %v", i+1, j+1, err)
+			}
+		}
+		doc.EndPage()
+		if err := doc.Err(); err != nil {
+			return err
+		}
+	}
+	return doc.EndDoc()
+}
+
+// draw draws the item
+func (it Item) draw(doc *pdfgen.PDFDoc) error {
+	switch it.Type {
+	case "text":
+		it.drawtext(doc)
+	case "rect":
+		doc.RectStyled(it.X, it.Y, it.W, it.H, it.style(true))
+	case "circle":
+		doc.CircleStyled(it.X, it.Y, it.R, it.style(true))
+	case "ellipse":
+		doc.EllipseStyled(it.X, it.Y, it.W, it.H, it.style(true))
+	case "line":
+		doc.LineStyled(it.X1, it.Y1, it.X2, it.Y2, it.style(false))
+	case "polyline", "polygon":
+		x, y, err := it.points()
+		if err != nil {
+			return err
+		}
+		if it.Type == "polygon" {
+			doc.PolygonStyled(x, y, it.style(true))
+		} else {
+			doc.PolylineStyled(x, y, it.style(false))
+		}
+	case "path":
+		doc.SVGPath(it.D, it.style(true))
+	case "image":
+		f, err := os.Open(it.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return doc.ImageFrom(it.X, it.Y, f, pdfgen.ImageOptions{Width: it.W, Height: it.H})
+	case "chart":
+		return it.drawchart(doc)
+	default:
+		return fmt.Errorf("unknown type %q", it.Type)
+	}
+	return nil
+}
+
+// drawtext draws a text item, wrapped to its width, if any, from its baseline downward
+func (it Item) drawtext(doc *pdfgen.PDFDoc) {
+	font, size, color := it.Font, it.Size, it.Color
+	if font == "" {
+		font = "sans"
+	}
+	if size <= 0 {
+		size = 12
+	}
+	if color == "" {
+		color = "black"
+	}
+	lines := strings.Split(it.Text, "\n")
+	if it.Width > 0 {
+		lines = pdfgen.WrapText(it.Text, font, size, it.Width)
+	}
+	for i, line := range lines {
+		x := it.X
+		switch it.Align {
+		case "center":
+			x -= pdfgen.TextWidth(line, font, size) / 2
+		case "right":
+			x -= pdfgen.TextWidth(line, font, size)
+		}
+		doc.Text(x, it.Y-float64(i)*size*1.2, line, font, size, color)
+	}
+}
+
+// drawchart draws a chart item
+func (it Item) drawchart(doc *pdfgen.PDFDoc) error {
+	switch it.Chart {
+	case "bar":
+		chart.BarChart{Categories: it.Categories, Series: it.Series, Legend: it.Legend}.Draw(doc, it.X, it.Y, it.W, it.H)
+	case "line":
+		chart.LineChart{Series: it.Series, Legend: it.Legend}.Draw(doc, it.X, it.Y, it.W, it.H)
+	case "pie":
+		chart.PieChart{Labels: it.Labels, Values: it.Values, Legend: it.Legend}.Draw(doc, it.X, it.Y, it.W, it.H)
+	default:
+		return fmt.Errorf("unknown chart %q", it.Chart)
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package pdfgen
 
 import (
 	"fmt"
+	"image/color"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -161,20 +164,194 @@ var colornames = map[string]RGB{
 	"yellowgreen":          {154, 205, 50},
 }
 
-// colorlookup returns a RGB triple corresponding to the named color or "rgb(r,g,b)" string.
+// colorlookup returns a RGB triple, and alpha (from 0 to 1), corresponding to the named color,
+// CSS-style "rgb(r,g,b)", "rgba(r,g,b,a)", "hsl(h,s%,l%)", or "hsla(h,s%,l%,a)" string,
+// "gray(v)" or "cmyk(c,m,y,k)" string (converted to RGB),
+// or "#rgb", "#rrggbb", or "#rrggbbaa" hex string, or a registered color name.
+// Any of these may be followed by an opacity percentage, as in "red/50".
 // On error, return black.
-func colorlookup(s string) (int, int, int) {
-	var red, green, blue int
+func colorlookup(s string) (int, int, int, float64) {
+	s, a := colorbase(s)
 	color, ok := colornames[s]
 	if ok {
-		return color.red, color.green, color.blue
+		return color.red, color.green, color.blue, a
 	}
-	if strings.HasPrefix(s, "rgb(") {
-		n, err := fmt.Sscanf(s[3:], "(%d,%d,%d)", &red, &green, &blue)
-		if n != 3 || err != nil {
-			return 0, 0, 0
+	if strings.HasPrefix(s, "#") {
+		r, g, b, ha := hexcolor(s[1:])
+		return r, g, b, ha * a
+	}
+	if strings.HasSuffix(s, ")") {
+		r, g, b, fa := funccolor(s)
+		return r, g, b, fa * a
+	}
+	return 0, 0, 0, a
+}
+
+// colorbase returns the color a color string specifies, with registered names resolved
+// and opacity suffixes ("/50") removed, and the opacity (from 0 to 1) of the suffixes
+func colorbase(s string) (string, float64) {
+	a := 1.0
+	for i := 0; i < 4; i++ { // a registered color may itself have an opacity suffix
+		for {
+			slash := strings.LastIndex(s, "/")
+			if slash <= strings.LastIndex(s, ")") {
+				break
+			}
+			v, err := strconv.ParseFloat(strings.TrimSuffix(s[slash+1:], "%"), 64)
+			if err != nil {
+				return s, a
+			}
+			s, a = s[:slash], a*unit(v/100)
+		}
+		r := resolvecolor(s)
+		if r == s {
+			break
 		}
-		return red, green, blue
+		s = r
+	}
+	return s, a
+}
+
+// funccolor returns the RGB triple and alpha of a CSS functional color: rgb, rgba, hsl, or hsla.
+// Arguments may be separated by commas or spaces, with the alpha optionally after a slash,
+// as in "rgb(255 0 0 / 50%)". Components may be percentages.
+// On error, return black.
+func funccolor(s string) (int, int, int, float64) {
+	open := strings.Index(s, "(")
+	if open < 0 {
+		return 0, 0, 0, 1
+	}
+	name := strings.ToLower(strings.TrimSpace(s[:open]))
+	args := strings.Fields(strings.NewReplacer(",", " ", "/", " ").Replace(s[open+1 : len(s)-1]))
+	n := 3
+	switch name {
+	case "gray":
+		n = 1
+	case "cmyk":
+		n = 4
+	}
+	if len(args) != n && len(args) != n+1 {
+		return 0, 0, 0, 1
+	}
+	alpha := 1.0
+	if len(args) == n+1 {
+		alpha = unit(cssnumber(args[n], 1))
+	}
+	switch name {
+	case "gray":
+		v, _ := graycolor(s)
+		g := int(math.Round(255 * v))
+		return g, g, g, alpha
+	case "cmyk":
+		c, m, y, k, _ := cmykcolor(s)
+		return int(math.Round(255 * (1 - c) * (1 - k))), int(math.Round(255 * (1 - m) * (1 - k))), int(math.Round(255 * (1 - y) * (1 - k))), alpha
+	case "rgb", "rgba":
+		return cssbyte(args[0]), cssbyte(args[1]), cssbyte(args[2]), alpha
+	case "hsl", "hsla":
+		h := cssnumber(strings.TrimSuffix(args[0], "deg"), 360)
+		r, g, b := hsl2rgb(h, unit(cssnumber(strings.TrimSuffix(args[1], "%"), 1)/100), unit(cssnumber(strings.TrimSuffix(args[2], "%"), 1)/100))
+		return r, g, b, alpha
+	}
+	return 0, 0, 0, 1
+}
+
+// graycolor returns the gray level of a "gray(v)" color string, from 0 (black) to 1 (white)
+// or a percentage, and whether the string is a gray color
+func graycolor(s string) (float64, bool) {
+	if !strings.HasPrefix(s, "gray(") || !strings.HasSuffix(s, ")") {
+		return 0, false
+	}
+	args := strings.Fields(strings.NewReplacer(",", " ", "/", " ").Replace(s[5 : len(s)-1]))
+	if len(args) < 1 {
+		return 0, true
+	}
+	return unit(cssnumber(args[0], 1)), true
+}
+
+// luminance returns the gray level, from 0 to 1, of a RGB triple
+func luminance(r, g, b int, _ float64) float64 {
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 255
+}
+
+// cmykcolor returns the components of a "cmyk(c,m,y,k)" color string, each from 0 to 1
+// or a percentage, and whether the string is a CMYK color
+func cmykcolor(s string) (c, m, y, k float64, ok bool) {
+	if !strings.HasPrefix(s, "cmyk(") || !strings.HasSuffix(s, ")") {
+		return 0, 0, 0, 0, false
+	}
+	args := strings.Fields(strings.NewReplacer(",", " ", "/", " ").Replace(s[5 : len(s)-1]))
+	if len(args) < 4 {
+		return 0, 0, 0, 1, true
+	}
+	return unit(cssnumber(args[0], 1)), unit(cssnumber(args[1], 1)), unit(cssnumber(args[2], 1)), unit(cssnumber(args[3], 1)), true
+}
+
+// cssnumber parses a number, where a percentage is taken as a fraction of full
+func cssnumber(s string, full float64) float64 {
+	if strings.HasSuffix(s, "%") {
+		v, _ := strconv.ParseFloat(s[:len(s)-1], 64)
+		return v / 100 * full
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// cssbyte parses a color component from 0 to 255, or a percentage
+func cssbyte(s string) int {
+	return int(math.Round(math.Max(0, math.Min(255, cssnumber(s, 255)))))
+}
+
+// hsl2rgb converts hue (degrees), saturation, and lightness (from 0 to 1) to a RGB triple
+func hsl2rgb(h, s, l float64) (int, int, int) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return int(math.Round((r + m) * 255)), int(math.Round((g + m) * 255)), int(math.Round((b + m) * 255))
+}
+
+// hexcolor returns the RGB triple and alpha of a hex color (without the leading #).
+// On error, return black.
+func hexcolor(s string) (int, int, int, float64) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, 1
+	}
+	switch len(s) {
+	case 3:
+		return int(v>>8&0xf) * 17, int(v>>4&0xf) * 17, int(v&0xf) * 17, 1
+	case 6:
+		return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff), 1
+	case 8:
+		return int(v >> 24 & 0xff), int(v >> 16 & 0xff), int(v >> 8 & 0xff), float64(v&0xff) / 255
+	}
+	return 0, 0, 0, 1
+}
+
+// ColorString returns the color string for a Go color value, for use wherever
+// a color string is taken, for example p.Rect(x, y, w, h, ColorString(color.RGBA{255, 0, 0, 255}))
+func ColorString(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
 	}
-	return 0, 0, 0
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
 }
@@ -0,0 +1,29 @@
+package pdfgen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestColorLookup(t *testing.T) {
+	tests := []struct {
+		s       string
+		r, g, b int
+		a       float64
+	}{
+		{"red", 255, 0, 0, 1},
+		{"red/50", 255, 0, 0, 0.5},
+		{"red/50/70", 255, 0, 0, 0.35},
+		{"steelblue/40/50", 70, 130, 180, 0.2},
+		{"#ff0000/50%", 255, 0, 0, 0.5},
+		{"rgb(0 0 255 / 50%)", 0, 0, 255, 0.5},
+		{"rgb(0 0 255 / 50%)/50", 0, 0, 255, 0.25},
+		{"red/x", 0, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		r, g, b, a := colorlookup(tt.s)
+		if r != tt.r || g != tt.g || b != tt.b || math.Abs(a-tt.a) > 1e-9 {
+			t.Errorf("colorlookup(%q) = %d,%d,%d at %g; want %d,%d,%d at %g", tt.s, r, g, b, a, tt.r, tt.g, tt.b, tt.a)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package pdfgen
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContactSheet describes the layout of a contact sheet: images in a grid of cells,
+// each with a caption, as for photo proofs and asset catalogs.
+type ContactSheet struct {
+	Rows, Columns int     // cells per page, default 4 rows by 3 columns
+	Margin        float64 // page margin, default 36
+	Gap           float64 // space between cells, default 12
+	Font          string  // caption font, default sans
+	FontSize      float64 // caption size, default 8
+	Color         string  // caption color, default black
+	Frame         string  // color of a frame around each image cell; none if empty
+}
+
+// defaults fills in the default values
+func (s ContactSheet) defaults() ContactSheet {
+	if s.Rows <= 0 {
+		s.Rows = 4
+	}
+	if s.Columns <= 0 {
+		s.Columns = 3
+	}
+	if s.Margin == 0 {
+		s.Margin = 36
+	}
+	if s.Gap == 0 {
+		s.Gap = 12
+	}
+	if s.Font == "" {
+		s.Font = "sans"
+	}
+	if s.FontSize == 0 {
+		s.FontSize = 8
+	}
+	if s.Color == "" {
+		s.Color = "black"
+	}
+	return s
+}
+
+// Pages returns the number of pages taken by a contact sheet of n images
+func (s ContactSheet) Pages(n int) int {
+	s = s.defaults()
+	per := s.Rows * s.Columns
+	return (n + per - 1) / per
+}
+
+// imageexts are the file extensions of the image formats that may be read
+var imageexts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".tif": true, ".tiff": true, ".webp": true}
+
+// ImageFiles returns the sorted names of the image files, by extension, in the directory
+func ImageFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && imageexts[strings.ToLower(filepath.Ext(e.Name()))] {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ContactSheet lays out the named image files in a grid, captioned with their base names,
+// beginning with page number page. The pages are begun and ended here (see ContactSheet.Pages
+// for their number), and the number of the page following the last is returned.
+// An image that cannot be read leaves its cell empty, captioned with the error;
+// the first such error is returned.
+func (p *PDFDoc) ContactSheet(page int, files []string, s ContactSheet) (int, error) {
+	s = s.defaults()
+	cw := (p.width - 2*s.Margin - float64(s.Columns-1)*s.Gap) / float64(s.Columns)
+	ch := (p.height - 2*s.Margin - float64(s.Rows-1)*s.Gap) / float64(s.Rows)
+	lead := s.FontSize * 1.5 // caption space below the image
+	var first error
+	for i, name := range files {
+		cell := i % (s.Rows * s.Columns)
+		if cell == 0 {
+			if i > 0 {
+				p.EndPage()
+				page++
+			}
+			p.NewPage(page)
+		}
+		row, col := cell/s.Columns, cell%s.Columns
+		x := s.Margin + float64(col)*(cw+s.Gap)
+		y := p.height - s.Margin - float64(row+1)*ch - float64(row)*s.Gap
+		caption := filepath.Base(name)
+		if err := p.sheetimage(x, y+lead, cw, ch-lead, name); err != nil {
+			if first == nil {
+				first = fmt.Errorf("%s: %w", name, err)
+			}
+			caption += ": " + err.Error()
+		}
+		if visible(s.Frame) {
+			p.StrokeRect(x, y+lead, cw, ch-lead, 0.5, s.Frame)
+		}
+		caption = fitcaption(caption, s.Font, s.FontSize, cw)
+		p.Text(x+(cw-TextWidth(caption, s.Font, s.FontSize))/2, y+lead-s.FontSize*1.1, caption, s.Font, s.FontSize, s.Color)
+	}
+	if len(files) > 0 {
+		p.EndPage()
+		page++
+	}
+	return page, first
+}
+
+// sheetimage places the named image file in the w by h cell with its lower left at (x,y),
+// scaled to fit and centered
+func (p *PDFDoc) sheetimage(x, y, w, h float64, name string) error {
+	r, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	info, err := p.readimage(r, image.Rectangle{})
+	if err != nil {
+		return err
+	}
+	iw, ih := float64(info.w), float64(info.h)
+	if info.orient >= 5 {
+		iw, ih = ih, iw
+	}
+	sw, sh := fitsize(iw, ih, w, h, false)
+	p.placematrix(info.name, ImageOptions{}.matrix(x+(w-sw)/2, y+(h-sh)/2, sw, sh, info.orient))
+	return nil
+}
+
+// fitcaption shortens s, with a trailing ellipsis, to fit within width
+func fitcaption(s, font string, size, width float64) string {
+	if TextWidth(s, font, size) <= width {
+		return s
+	}
+	r := []rune(s)
+	for n := len(r) - 1; n > 0; n-- {
+		if t := string(r[:n]) + "..."; TextWidth(t, font, size) <= width {
+			return t
+		}
+	}
+	return ""
+}
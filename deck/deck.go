@@ -0,0 +1,166 @@
+// Package deck renders deck markup, the XML slide format of the deck tools and decksh,
+// as PDF slides.
+//
+// Positions are percentages of the canvas, with the origin at the lower left;
+// font sizes are percentages of the canvas width.
+package deck
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+)
+
+// Deck is a slide deck.
+type Deck struct {
+	Title   string  `xml:"title"`
+	Creator string  `xml:"creator"`
+	Date    string  `xml:"date"`
+	Canvas  Canvas  `xml:"canvas"`
+	Slide   []Slide `xml:"slide"`
+}
+
+// Canvas is the size of the slides, in points; 792 by 612 if zero.
+type Canvas struct {
+	Width  float64 `xml:"width,attr"`
+	Height float64 `xml:"height,attr"`
+}
+
+// Slide is a slide's background, foreground color, and elements.
+type Slide struct {
+	Bg      string    `xml:"bg,attr"`
+	Fg      string    `xml:"fg,attr"`
+	Image   []Image   `xml:"image"`
+	Rect    []Rect    `xml:"rect"`
+	Ellipse []Ellipse `xml:"ellipse"`
+	Curve   []Curve   `xml:"curve"`
+	Arc     []Arc     `xml:"arc"`
+	Line    []Line    `xml:"line"`
+	Polygon []Polygon `xml:"polygon"`
+	Text    []Text    `xml:"text"`
+	List    []List    `xml:"list"`
+}
+
+// Text is a line or block of text, at its baseline.
+type Text struct {
+	Xp      float64 `xml:"xp,attr"`
+	Yp      float64 `xml:"yp,attr"`
+	Sp      float64 `xml:"sp,attr"`
+	Lp      float64 `xml:"lp,attr"`   // line spacing, as a multiple of the font size
+	Wp      float64 `xml:"wp,attr"`   // width of a block, to which it is wrapped
+	Type    string  `xml:"type,attr"` // plain, block, or code
+	Align   string  `xml:"align,attr"`
+	Font    string  `xml:"font,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+	Tdata   string  `xml:",chardata"`
+}
+
+// List is a list of items, from the top down.
+type List struct {
+	Xp      float64    `xml:"xp,attr"`
+	Yp      float64    `xml:"yp,attr"`
+	Sp      float64    `xml:"sp,attr"`
+	Lp      float64    `xml:"lp,attr"`   // item spacing, as a multiple of the font size
+	Type    string     `xml:"type,attr"` // plain, bullet, or number
+	Align   string     `xml:"align,attr"`
+	Font    string     `xml:"font,attr"`
+	Color   string     `xml:"color,attr"`
+	Opacity float64    `xml:"opacity,attr"`
+	Li      []ListItem `xml:"li"`
+}
+
+// ListItem is an item of a list, which may override the list's color and font.
+type ListItem struct {
+	Color    string `xml:"color,attr"`
+	Font     string `xml:"font,attr"`
+	ListText string `xml:",chardata"`
+}
+
+// Image is an image file, centered at its position.
+type Image struct {
+	Xp      float64 `xml:"xp,attr"`
+	Yp      float64 `xml:"yp,attr"`
+	Width   int     `xml:"width,attr"`
+	Height  int     `xml:"height,attr"`
+	Scale   float64 `xml:"scale,attr"`
+	Name    string  `xml:"name,attr"`
+	Caption string  `xml:"caption,attr"`
+}
+
+// Rect is a rectangle, centered at its position. If Hr is set, the height is
+// that percentage of the width, instead of Hp.
+type Rect struct {
+	Xp      float64 `xml:"xp,attr"`
+	Yp      float64 `xml:"yp,attr"`
+	Wp      float64 `xml:"wp,attr"`
+	Hp      float64 `xml:"hp,attr"`
+	Hr      float64 `xml:"hr,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+}
+
+// Ellipse is an ellipse, centered at its position, sized like a Rect.
+type Ellipse Rect
+
+// Line is a line segment; Sp is its width in points, 2 if zero.
+type Line struct {
+	Xp1     float64 `xml:"xp1,attr"`
+	Yp1     float64 `xml:"yp1,attr"`
+	Xp2     float64 `xml:"xp2,attr"`
+	Yp2     float64 `xml:"yp2,attr"`
+	Sp      float64 `xml:"sp,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+}
+
+// Arc is an elliptical arc, centered at its position, from angle A1 to A2 in degrees.
+type Arc struct {
+	Xp      float64 `xml:"xp,attr"`
+	Yp      float64 `xml:"yp,attr"`
+	Wp      float64 `xml:"wp,attr"`
+	Hp      float64 `xml:"hp,attr"`
+	A1      float64 `xml:"a1,attr"`
+	A2      float64 `xml:"a2,attr"`
+	Sp      float64 `xml:"sp,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+}
+
+// Curve is a quadratic Bezier curve from point 1 to point 3, with point 2 its control point.
+type Curve struct {
+	Xp1     float64 `xml:"xp1,attr"`
+	Yp1     float64 `xml:"yp1,attr"`
+	Xp2     float64 `xml:"xp2,attr"`
+	Yp2     float64 `xml:"yp2,attr"`
+	Xp3     float64 `xml:"xp3,attr"`
+	Yp3     float64 `xml:"yp3,attr"`
+	Sp      float64 `xml:"sp,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+}
+
+// Polygon is a filled polygon, its coordinates lists of percentages separated by spaces.
+type Polygon struct {
+	XC      string  `xml:"xc,attr"`
+	YC      string  `xml:"yc,attr"`
+	Color   string  `xml:"color,attr"`
+	Opacity float64 `xml:"opacity,attr"`
+}
+
+// Read reads deck markup
+func Read(r io.Reader) (Deck, error) {
+	var d Deck
+	err := xml.NewDecoder(r).Decode(&d)
+	return d, err
+}
+
+// ReadFile reads deck markup from the named file
+func ReadFile(name string) (Deck, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Deck{}, err
+	}
+	defer f.Close()
+	return Read(f)
+}
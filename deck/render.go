@@ -0,0 +1,284 @@
+package deck
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Defaults of the markup, where attributes are missing
+const (
+	defaultwidth    = 792.0
+	defaultheight   = 612.0
+	defaultsize     = 2.0 // text, as a percentage of the canvas width
+	defaultspacing  = 1.4 // lines of a block, as a multiple of the font size
+	listspacing     = 2.0 // items of a list, as a multiple of the font size
+	defaultstroke   = 2.0
+	defaultshape    = "gray"
+	defaultcodefill = "whitesmoke"
+)
+
+// size returns the size of the canvas
+func (d Deck) size() (float64, float64) {
+	w, h := d.Canvas.Width, d.Canvas.Height
+	if w <= 0 {
+		w = defaultwidth
+	}
+	if h <= 0 {
+		h = defaultheight
+	}
+	return w, h
+}
+
+// Render writes the deck as a PDF document, a page for each slide.
+// Image names are relative to the current directory.
+func (d Deck) Render(w io.Writer) error {
+	cw, ch := d.size()
+	doc := pdfgen.NewDoc(w, cw, ch)
+	doc.Init(len(d.Slide))
+	for i := range d.Slide {
+		doc.NewPage(i + 1)
+		if err := d.DrawSlide(doc, i); err != nil {
+			return err
+		}
+		doc.EndPage()
+		if err := doc.Err(); err != nil {
+			return err
+		}
+	}
+	return doc.EndDoc()
+}
+
+// DrawSlide draws slide n (from 0) of the deck on the current page
+func (d Deck) DrawSlide(doc *pdfgen.PDFDoc, n int) error {
+	if n < 0 || n >= len(d.Slide) {
+		return fmt.Errorf("deck: no slide %d", n)
+	}
+	cw, ch := d.size()
+	c := canvas{doc: doc, w: cw, h: ch}
+	s := d.Slide[n]
+	fg := s.Fg
+	if fg == "" {
+		fg = "black"
+	}
+	if s.Bg != "" {
+		doc.Rect(0, 0, cw, ch, s.Bg)
+	}
+	for _, img := range s.Image {
+		if err := c.image(img, fg); err != nil {
+			return err
+		}
+	}
+	for _, r := range s.Rect {
+		x, y, w, h := c.box(Rect(r))
+		doc.Rect(x-w/2, y-h/2, w, h, paint(r.Color, r.Opacity, defaultshape))
+	}
+	for _, e := range s.Ellipse {
+		x, y, w, h := c.box(Rect(e))
+		doc.Ellipse(x, y, w/2, h/2, paint(e.Color, e.Opacity, defaultshape))
+	}
+	for _, cv := range s.Curve {
+		doc.Curve(c.x(cv.Xp1), c.y(cv.Yp1), c.x(cv.Xp2), c.y(cv.Yp2), c.x(cv.Xp3), c.y(cv.Yp3),
+			stroke(cv.Sp), paint(cv.Color, cv.Opacity, defaultshape))
+	}
+	for _, a := range s.Arc {
+		doc.Arc(c.x(a.Xp), c.y(a.Yp), c.x(a.Wp)/2, c.y(a.Hp)/2, a.A1, a.A2, stroke(a.Sp), paint(a.Color, a.Opacity, defaultshape))
+	}
+	for _, l := range s.Line {
+		doc.Line(c.x(l.Xp1), c.y(l.Yp1), c.x(l.Xp2), c.y(l.Yp2), stroke(l.Sp), paint(l.Color, l.Opacity, defaultshape))
+	}
+	for _, p := range s.Polygon {
+		xs, ys := c.coords(p.XC, c.x), c.coords(p.YC, c.y)
+		if len(xs) > 2 && len(xs) == len(ys) {
+			doc.Polygon(xs, ys, paint(p.Color, p.Opacity, defaultshape))
+		}
+	}
+	for _, t := range s.Text {
+		c.text(t, fg)
+	}
+	for _, l := range s.List {
+		c.list(l, fg)
+	}
+	return nil
+}
+
+// canvas converts the percentages of the markup to page coordinates
+type canvas struct {
+	doc  *pdfgen.PDFDoc
+	w, h float64
+}
+
+func (c canvas) x(p float64) float64 { return c.w * p / 100 }
+func (c canvas) y(p float64) float64 { return c.h * p / 100 }
+
+// fontsize returns the font size of sp, the default if zero
+func (c canvas) fontsize(sp float64) float64 {
+	if sp <= 0 {
+		sp = defaultsize
+	}
+	return c.w * sp / 100
+}
+
+// box returns the center and size of a rectangle
+func (c canvas) box(r Rect) (x, y, w, h float64) {
+	w = c.x(r.Wp)
+	h = c.y(r.Hp)
+	if r.Hr > 0 {
+		h = w * r.Hr / 100
+	}
+	return c.x(r.Xp), c.y(r.Yp), w, h
+}
+
+// coords converts a list of percentages
+func (c canvas) coords(s string, conv func(float64) float64) []float64 {
+	var v []float64
+	for _, f := range strings.Fields(strings.Replace(s, ",", " ", -1)) {
+		p, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil
+		}
+		v = append(v, conv(p))
+	}
+	return v
+}
+
+// paint returns the color with the opacity percentage, def if the color is empty
+func paint(color string, opacity float64, def string) string {
+	if color == "" {
+		color = def
+	}
+	if opacity > 0 && opacity < 100 {
+		return fmt.Sprintf("%s/%g", color, opacity)
+	}
+	return color
+}
+
+// stroke returns the stroke width of sp, the default if zero
+func stroke(sp float64) float64 {
+	if sp <= 0 {
+		return defaultstroke
+	}
+	return sp
+}
+
+// textat draws s aligned at x: left (or start), center (or middle), or right (or end)
+func (c canvas) textat(x, y float64, s, align, font string, size float64, color string) {
+	switch align {
+	case "center", "middle", "c", "mid":
+		x -= pdfgen.TextWidth(s, font, size) / 2
+	case "right", "end", "e":
+		x -= pdfgen.TextWidth(s, font, size)
+	}
+	c.doc.Text(x, y, s, font, size, color)
+}
+
+// text draws a text element, with the lines of blocks and code from its baseline downward
+func (c canvas) text(t Text, fg string) {
+	size := c.fontsize(t.Sp)
+	font := t.Font
+	if font == "" {
+		font = "sans"
+	}
+	if t.Type == "code" {
+		font = "mono"
+	}
+	color := paint(t.Color, t.Opacity, fg)
+	spacing := t.Lp
+	if spacing <= 0 {
+		spacing = defaultspacing
+	}
+	x, y := c.x(t.Xp), c.y(t.Yp)
+	var lines []string
+	switch t.Type {
+	case "block":
+		lines = pdfgen.WrapText(strings.Join(strings.Fields(t.Tdata), " "), font, size, c.x(t.Wp))
+	case "code":
+		lines = strings.Split(strings.Trim(t.Tdata, "\n"), "\n")
+		width := 0.0
+		for _, l := range lines {
+			width = math.Max(width, pdfgen.TextWidth(l, font, size))
+		}
+		pad := size
+		top := y + size + pad/2
+		bottom := y - spacing*size*float64(len(lines)-1) - pad
+		c.doc.Rect(x-pad, bottom, width+2*pad, top-bottom, defaultcodefill)
+	default:
+		lines = strings.Split(strings.TrimSpace(t.Tdata), "\n")
+	}
+	for i, l := range lines {
+		c.textat(x, y-float64(i)*spacing*size, strings.TrimRight(l, " \t"), t.Align, font, size, color)
+	}
+}
+
+// list draws a list, its first item at its position and the rest below
+func (c canvas) list(l List, fg string) {
+	size := c.fontsize(l.Sp)
+	spacing := l.Lp
+	if spacing <= 0 {
+		spacing = listspacing
+	}
+	x, y := c.x(l.Xp), c.y(l.Yp)
+	for i, li := range l.Li {
+		font := li.Font
+		if font == "" {
+			font = l.Font
+		}
+		if font == "" {
+			font = "sans"
+		}
+		color := li.Color
+		if color == "" {
+			color = l.Color
+		}
+		color = paint(color, l.Opacity, fg)
+		s := strings.TrimSpace(li.ListText)
+		ly := y - float64(i)*spacing*size
+		switch l.Type {
+		case "bullet":
+			c.doc.Circle(x, ly+size/3, size/5, color)
+			c.doc.Text(x+size, ly, s, font, size, color)
+		case "number":
+			c.doc.Text(x, ly, fmt.Sprintf("%d. %s", i+1, s), font, size, color)
+		default:
+			c.textat(x, ly, s, l.Align, font, size, color)
+		}
+	}
+}
+
+// image places an image, centered at its position, with its caption beneath
+func (c canvas) image(img Image, fg string) error {
+	f, err := os.Open(img.Name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, h := float64(img.Width), float64(img.Height)
+	if w == 0 || h == 0 {
+		cfg, _, err := image.DecodeConfig(f)
+		if err != nil {
+			return fmt.Errorf("deck: %s: %v", img.Name, err)
+		}
+		w, h = float64(cfg.Width), float64(cfg.Height)
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	if img.Scale > 0 {
+		w, h = w*img.Scale/100, h*img.Scale/100
+	}
+	x, y := c.x(img.Xp), c.y(img.Yp)
+	if err := c.doc.ImageFrom(x-w/2, y-h/2, f, pdfgen.ImageOptions{Width: w, Height: h}); err != nil {
+		return fmt.Errorf("deck: %s: %v", img.Name, err)
+	}
+	if img.Caption != "" {
+		size := c.fontsize(defaultsize * 0.75)
+		c.textat(x, y-h/2-1.5*size, img.Caption, "center", "sans", size, fg)
+	}
+	return nil
+}
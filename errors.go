@@ -0,0 +1,40 @@
+package pdfgen
+
+import "io"
+
+// The document keeps the first error that occurs while it is generated, whether writing
+// to the output or encoding its content: the sticky error. Drawing methods do not return
+// errors; once there is one, further output is discarded, and EndDoc returns it.
+
+// errwriter writes to w until a write fails, and then records the error and discards the rest.
+// It counts the bytes written.
+type errwriter struct {
+	w   io.Writer
+	err *error
+	n   int64
+}
+
+func (e *errwriter) Write(b []byte) (int, error) {
+	if *e.err != nil {
+		return 0, *e.err
+	}
+	n, err := e.w.Write(b)
+	e.n += int64(n)
+	if err != nil {
+		*e.err = err
+	}
+	return n, err
+}
+
+// seterr records err as the document's error, unless it is nil or there already is one
+func (p *PDFDoc) seterr(err error) {
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+}
+
+// Err returns the document's error: the first error writing or encoding it, if any.
+// Long documents may check it between pages, to stop drawing into discarded output.
+func (p *PDFDoc) Err() error {
+	return p.err
+}
@@ -0,0 +1,88 @@
+package pdfgen
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// orientations maps the EXIF orientation values 1 to 8 to the matrix that places the
+// image unit square, as stored, upright in the unit square.
+var orientations = [9]matrix{
+	1: {1, 0, 0, 1, 0, 0},   // as stored
+	2: {-1, 0, 0, 1, 1, 0},  // mirrored horizontally
+	3: {-1, 0, 0, -1, 1, 1}, // rotated 180°
+	4: {1, 0, 0, -1, 0, 1},  // mirrored vertically
+	5: {0, -1, -1, 0, 1, 1}, // transposed
+	6: {0, -1, 1, 0, 0, 1},  // rotated 90° clockwise
+	7: {0, 1, 1, 0, 0, 0},   // transversed
+	8: {0, 1, -1, 0, 1, 0},  // rotated 90° counterclockwise
+}
+
+// SetImageOrientation sets whether JPEG images are placed upright according to
+// their EXIF orientation (as photos from phones require), which is the default
+func (p *PDFDoc) SetImageOrientation(auto bool) {
+	p.ignoreexif = !auto
+}
+
+// jpegorientation returns the EXIF orientation (1 to 8) of JPEG data, or 1 if there is none
+func jpegorientation(data []byte) int {
+	if seg := jpegsegment(data, 0xe1, "Exif\x00\x00"); seg != nil {
+		return exiforientation(seg[6:])
+	}
+	return 1
+}
+
+// jpegsegment returns the first metadata segment of JPEG data with the marker
+// whose contents begin with prefix, or nil if there is none
+func jpegsegment(data []byte, marker byte, prefix string) []byte {
+	if !bytes.HasPrefix(data, []byte{0xff, 0xd8}) {
+		return nil
+	}
+	for i := 2; i+4 <= len(data) && data[i] == 0xff; {
+		m := data[i+1]
+		n := int(binary.BigEndian.Uint16(data[i+2:]))
+		if m == 0xda || n < 2 || i+2+n > len(data) { // start of scan: no more metadata
+			break
+		}
+		if seg := data[i+4 : i+2+n]; m == marker && bytes.HasPrefix(seg, []byte(prefix)) {
+			return seg
+		}
+		i += 2 + n
+	}
+	return nil
+}
+
+// exiforientation returns the orientation tag in the first IFD of EXIF (TIFF) data, or 1 if there is none
+func exiforientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	off := order.Uint32(tiff[4:])
+	if off > uint32(len(tiff)-2) { // compared unconverted, as an int may not hold it
+		return 1
+	}
+	ifd := int(off)
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + 12*i
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			break
+		}
+	}
+	return 1
+}
@@ -0,0 +1,44 @@
+package pdfgen
+
+import "testing"
+
+// tiffdata returns EXIF (TIFF) data in little-endian order with the first IFD at offset ifd,
+// holding the entries of tag and value pairs
+func tiffdata(ifd uint32, entries ...uint16) []byte {
+	b := []byte{'I', 'I', 42, 0, byte(ifd), byte(ifd >> 8), byte(ifd >> 16), byte(ifd >> 24)}
+	if ifd != 8 {
+		return b
+	}
+	n := len(entries) / 2
+	b = append(b, byte(n), byte(n>>8))
+	for i := 0; i < n; i++ {
+		tag, v := entries[2*i], entries[2*i+1]
+		b = append(b, byte(tag), byte(tag>>8), 3, 0, 1, 0, 0, 0, byte(v), byte(v>>8), 0, 0)
+	}
+	return b
+}
+
+func TestExifOrientation(t *testing.T) {
+	tests := []struct {
+		name string
+		tiff []byte
+		want int
+	}{
+		{"orientation", tiffdata(8, 0x0112, 6), 6},
+		{"after another tag", tiffdata(8, 0x010f, 1, 0x0112, 3), 3},
+		{"no orientation", tiffdata(8, 0x010f, 1), 1},
+		{"out of range", tiffdata(8, 0x0112, 9), 1},
+		{"short", []byte("II*\x00"), 1},
+		{"bad byte order", []byte("XX*\x00\x08\x00\x00\x00\x00\x00"), 1},
+		{"offset past the end", tiffdata(100), 1},
+		{"offset at the end", tiffdata(7), 1},
+		{"huge offset", tiffdata(0xfffffff0), 1},
+		{"largest offset", tiffdata(0xffffffff), 1},
+		{"truncated entries", tiffdata(8, 0x0112, 6)[:15], 1},
+	}
+	for _, tt := range tests {
+		if got := exiforientation(tt.tiff); got != tt.want {
+			t.Errorf("%s: exiforientation = %d; want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Package geo projects geographic polygons onto pdfgen pages, for printed maps and choropleths.
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Point is a geographic position, in degrees.
+type Point struct {
+	Lon, Lat float64
+}
+
+// Ring is a closed line of points; the last point need not repeat the first.
+type Ring []Point
+
+// Polygon is an outer ring followed by the rings of any holes.
+type Polygon []Ring
+
+// Feature is a named geographic area of one or more polygons.
+type Feature struct {
+	ID         string
+	Properties map[string]interface{}
+	Polygons   []Polygon
+}
+
+// Property returns the feature's property as a string, or "" if there is none
+func (f Feature) Property(name string) string {
+	v, ok := f.Properties[name]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// Projection maps longitude and latitude, in degrees, to plane coordinates, with y upward.
+type Projection interface {
+	Project(lon, lat float64) (x, y float64)
+}
+
+const radians = math.Pi / 180
+
+// Equirectangular is the plate carrée projection, scaled horizontally by the cosine of
+// the standard parallel to keep shapes true there.
+type Equirectangular struct {
+	Parallel float64 // latitude of true scale, default the equator
+}
+
+// Project maps lon and lat to the plane
+func (e Equirectangular) Project(lon, lat float64) (float64, float64) {
+	return lon * math.Cos(e.Parallel*radians), lat
+}
+
+// Mercator is the conformal cylindrical projection. Latitudes are limited to ±85°,
+// beyond which the projection grows without bound.
+type Mercator struct{}
+
+// Project maps lon and lat to the plane
+func (Mercator) Project(lon, lat float64) (float64, float64) {
+	lat = math.Max(-85, math.Min(85, lat))
+	return lon * radians, math.Log(math.Tan(math.Pi/4 + lat*radians/2))
+}
+
+// Albers is the Albers equal-area conic projection, for areas of wide east-west extent.
+// The zero value is the usual projection of the conterminous United States.
+type Albers struct {
+	Lon0, Lat0 float64 // origin, default 96°W, 23°N
+	Lat1, Lat2 float64 // standard parallels, default 29.5°N and 45.5°N
+}
+
+// defaults fills in the default values
+func (a Albers) defaults() Albers {
+	if a.Lat1 == 0 && a.Lat2 == 0 {
+		return Albers{Lon0: -96, Lat0: 23, Lat1: 29.5, Lat2: 45.5}
+	}
+	return a
+}
+
+// Project maps lon and lat to the plane
+func (a Albers) Project(lon, lat float64) (float64, float64) {
+	a = a.defaults()
+	s1, s2 := math.Sin(a.Lat1*radians), math.Sin(a.Lat2*radians)
+	n := (s1 + s2) / 2
+	c := math.Cos(a.Lat1*radians)*math.Cos(a.Lat1*radians) + 2*n*s1
+	rho0 := math.Sqrt(c-2*n*math.Sin(a.Lat0*radians)) / n
+	rho := math.Sqrt(c-2*n*math.Sin(lat*radians)) / n
+	theta := n * (lon - a.Lon0) * radians
+	return rho * math.Sin(theta), rho0 - rho*math.Cos(theta)
+}
+
+// Map places projected features in a rectangle of the page.
+type Map struct {
+	Projection Projection
+	scale      float64
+	ox, oy     float64 // page position of the plane origin
+}
+
+// Fit returns a map that fits the features, projected, in the w by h rectangle with
+// its lower left at (x,y), centered and at equal scale in both directions
+func Fit(proj Projection, features []Feature, x, y, w, h float64) *Map {
+	minx, miny := math.Inf(1), math.Inf(1)
+	maxx, maxy := math.Inf(-1), math.Inf(-1)
+	for _, f := range features {
+		for _, poly := range f.Polygons {
+			for _, ring := range poly {
+				for _, pt := range ring {
+					px, py := proj.Project(pt.Lon, pt.Lat)
+					minx, maxx = math.Min(minx, px), math.Max(maxx, px)
+					miny, maxy = math.Min(miny, py), math.Max(maxy, py)
+				}
+			}
+		}
+	}
+	m := &Map{Projection: proj, scale: 1, ox: x, oy: y}
+	if minx > maxx {
+		return m
+	}
+	dx, dy := maxx-minx, maxy-miny
+	switch {
+	case dx == 0 && dy == 0:
+	case dx == 0:
+		m.scale = h / dy
+	case dy == 0:
+		m.scale = w / dx
+	default:
+		m.scale = math.Min(w/dx, h/dy)
+	}
+	m.ox = x + (w-dx*m.scale)/2 - minx*m.scale
+	m.oy = y + (h-dy*m.scale)/2 - miny*m.scale
+	return m
+}
+
+// Point returns the page position of lon and lat
+func (m *Map) Point(lon, lat float64) (float64, float64) {
+	px, py := m.Projection.Project(lon, lat)
+	return m.ox + px*m.scale, m.oy + py*m.scale
+}
+
+// path builds the path of the feature's polygons, a subpath for each ring
+func (m *Map) path(doc *pdfgen.PDFDoc, f Feature) bool {
+	drawn := false
+	for _, poly := range f.Polygons {
+		for _, ring := range poly {
+			if len(ring) < 3 {
+				continue
+			}
+			for i, pt := range ring {
+				x, y := m.Point(pt.Lon, pt.Lat)
+				if i == 0 {
+					doc.MoveTo(x, y)
+				} else {
+					doc.LineTo(x, y)
+				}
+			}
+			doc.ClosePath()
+			drawn = true
+		}
+	}
+	return drawn
+}
+
+// Fill fills the feature; holes are left unfilled, whatever the direction of their rings
+func (m *Map) Fill(doc *pdfgen.PDFDoc, f Feature, color string) {
+	if !m.path(doc, f) {
+		return
+	}
+	doc.Push()
+	doc.SetFillRule(pdfgen.EvenOdd)
+	doc.FillPath(color)
+	doc.Pop()
+}
+
+// Stroke outlines the feature
+func (m *Map) Stroke(doc *pdfgen.PDFDoc, f Feature, sw float64, color string) {
+	if m.path(doc, f) {
+		doc.StrokePath(sw, color)
+	}
+}
+
+// FillStroke fills and then outlines the feature
+func (m *Map) FillStroke(doc *pdfgen.PDFDoc, f Feature, sw float64, fillcolor, strokecolor string) {
+	if !m.path(doc, f) {
+		return
+	}
+	doc.Push()
+	doc.SetFillRule(pdfgen.EvenOdd)
+	doc.FillStrokePath(sw, fillcolor, strokecolor)
+	doc.Pop()
+}
+
+// Choropleth is a map of features colored by their values.
+type Choropleth struct {
+	Features    []Feature
+	Values      map[string]float64 // values of the features, by key
+	Key         string             // property that keys the values; if empty, the feature ID
+	Projection  Projection         // default Equirectangular
+	Ramp        pdfgen.Ramp        // colors of the values, from least to greatest, default Viridis
+	Min, Max    float64            // range of the values mapped to the ramp; from the data if equal
+	Missing     string             // color of features without a value, default lightgray
+	Outline     string             // color of the borders, default white
+	StrokeWidth float64            // width of the borders, default 0.5
+}
+
+// key returns the key of the feature's value
+func (c Choropleth) key(f Feature) string {
+	if c.Key == "" {
+		return f.ID
+	}
+	return f.Property(c.Key)
+}
+
+// Draw draws the choropleth fit to the w by h rectangle with its lower left at (x,y),
+// and returns the map, for placing markers or labels on it
+func (c Choropleth) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) *Map {
+	if c.Projection == nil {
+		c.Projection = Equirectangular{}
+	}
+	if len(c.Ramp) == 0 {
+		c.Ramp = pdfgen.Viridis
+	}
+	if c.Missing == "" {
+		c.Missing = "lightgray"
+	}
+	if c.Outline == "" {
+		c.Outline = "white"
+	}
+	if c.StrokeWidth == 0 {
+		c.StrokeWidth = 0.5
+	}
+	min, max := c.Min, c.Max
+	if min == max {
+		min, max = math.Inf(1), math.Inf(-1)
+		for _, v := range c.Values {
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+	}
+	m := Fit(c.Projection, c.Features, x, y, w, h)
+	for _, f := range c.Features {
+		color := c.Missing
+		if v, ok := c.Values[c.key(f)]; ok && !math.IsNaN(v) {
+			color = c.Ramp.Value(v, min, max)
+		}
+		m.FillStroke(doc, f, c.StrokeWidth, color, c.Outline)
+	}
+	return m
+}
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// geojson is a GeoJSON object: a FeatureCollection, Feature, or geometry
+type geojson struct {
+	Type        string                 `json:"type"`
+	Features    []geojson              `json:"features"`
+	ID          interface{}            `json:"id"`
+	Properties  map[string]interface{} `json:"properties"`
+	Geometry    *geojson               `json:"geometry"`
+	Geometries  []geojson              `json:"geometries"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+}
+
+// ReadGeoJSON reads the polygon features of GeoJSON: a FeatureCollection, a Feature, or a geometry.
+// Polygons and MultiPolygons are read, within GeometryCollections too; other geometries are skipped.
+func ReadGeoJSON(r io.Reader) ([]Feature, error) {
+	var g geojson
+	if err := json.NewDecoder(r).Decode(&g); err != nil {
+		return nil, err
+	}
+	switch g.Type {
+	case "FeatureCollection":
+		features := make([]Feature, 0, len(g.Features))
+		for _, gf := range g.Features {
+			f, err := gf.feature()
+			if err != nil {
+				return nil, err
+			}
+			features = append(features, f)
+		}
+		return features, nil
+	case "Feature":
+		f, err := g.feature()
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	case "":
+		return nil, errors.New("geo: not a GeoJSON object")
+	}
+	polygons, err := g.polygons()
+	if err != nil {
+		return nil, err
+	}
+	return []Feature{{Polygons: polygons}}, nil
+}
+
+// feature converts a GeoJSON Feature
+func (g geojson) feature() (Feature, error) {
+	f := Feature{Properties: g.Properties}
+	if g.ID != nil {
+		f.ID = fmt.Sprint(g.ID)
+	}
+	if g.Geometry == nil {
+		return f, nil
+	}
+	var err error
+	f.Polygons, err = g.Geometry.polygons()
+	return f, err
+}
+
+// polygons converts a GeoJSON geometry
+func (g geojson) polygons() ([]Polygon, error) {
+	switch g.Type {
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, err
+		}
+		return []Polygon{polygon(coords)}, nil
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, err
+		}
+		polygons := make([]Polygon, len(coords))
+		for i, c := range coords {
+			polygons[i] = polygon(c)
+		}
+		return polygons, nil
+	case "GeometryCollection":
+		var polygons []Polygon
+		for _, sub := range g.Geometries {
+			p, err := sub.polygons()
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, p...)
+		}
+		return polygons, nil
+	}
+	return nil, nil
+}
+
+// polygon converts GeoJSON polygon coordinates: rings of [lon, lat] positions
+func polygon(coords [][][]float64) Polygon {
+	poly := make(Polygon, 0, len(coords))
+	for _, c := range coords {
+		ring := make(Ring, 0, len(c))
+		for _, pos := range c {
+			if len(pos) >= 2 {
+				ring = append(ring, Point{Lon: pos[0], Lat: pos[1]})
+			}
+		}
+		poly = append(poly, ring)
+	}
+	return poly
+}
@@ -0,0 +1,15 @@
+package pdfgen
+
+import "fmt"
+
+// Group draws the content made by draw as a transparency group: the content is
+// composited together first, and the result is then composited onto the page, using the
+// current opacity and soft mask. An isolated group composites against a transparent backdrop,
+// rather than the page; in a knockout group, each element composites against the group's
+// backdrop rather than the earlier elements of the group.
+func (p *PDFDoc) Group(isolated, knockout bool, draw func(*PDFDoc)) {
+	content := p.capture(draw)
+	form := p.addstream(fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [%.2f %.2f %.2f %.2f] /Group << /S /Transparency /I %t /K %t >> /Resources 2 0 R",
+		-p.width, -p.height, 2*p.width, 2*p.height, isolated, knockout), content)
+	fmt.Fprintf(p.Writer, "/%s Do\n", p.xobjects.add("X", fmt.Sprintf("%d 0 R", form)))
+}
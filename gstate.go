@@ -0,0 +1,275 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// graphicsstate holds the settings that are saved and restored by Push and Pop.
+type graphicsstate struct {
+	fillrule   FillRule
+	dash       []float64
+	dashphase  float64
+	linecap    LineCap
+	linejoin   LineJoin
+	miterlimit float64
+	opacity    alpha
+	alpha      alpha
+	softmask   string
+	overprint  overprint
+	intent     RenderingIntent
+	ctm        matrix
+	shadow     *shadow
+	pen        pen
+	written    penops
+}
+
+// The graphics state settings below persist across pages: they are written
+// immediately when set within a page, and restored at the start of each new page.
+
+// pagestate writes the graphics state settings that differ from the defaults
+func (p *PDFDoc) pagestate() {
+	if len(p.dash) > 0 {
+		p.writedash()
+	}
+	if p.linecap != ButtCap {
+		fmt.Fprintf(p.Writer, "%d J\n", p.linecap)
+	}
+	if p.linejoin != MiterJoin {
+		fmt.Fprintf(p.Writer, "%d j\n", p.linejoin)
+	}
+	if p.miterlimit != defaultmiterlimit {
+		fmt.Fprintf(p.Writer, "%.2f M\n", p.miterlimit)
+	}
+	if p.opacity != opaque {
+		p.writealpha(p.opacity)
+	}
+	if p.softmask != "" {
+		fmt.Fprintf(p.Writer, "/%s gs\n", p.softmask)
+	}
+	if p.overprint != (overprint{}) {
+		p.writeoverprint()
+	}
+	if p.intent != RelativeColorimetric {
+		fmt.Fprintf(p.Writer, "/%s ri\n", p.intent)
+	}
+}
+
+// SetDash sets the dash pattern for subsequent strokes: alternating lengths
+// of dashes and gaps, starting phase units into the pattern.
+// An empty pattern restores solid strokes.
+func (p *PDFDoc) SetDash(pattern []float64, phase float64) {
+	p.dash = append([]float64(nil), pattern...)
+	p.dashphase = phase
+	if p.inpage {
+		p.writedash()
+	}
+}
+
+// writedash writes the dash operator
+func (p *PDFDoc) writedash() {
+	lengths := make([]string, len(p.dash))
+	for i, v := range p.dash {
+		lengths[i] = fmt.Sprintf("%.2f", v)
+	}
+	fmt.Fprintf(p.Writer, "[%s] %.2f d\n", strings.Join(lengths, " "), p.dashphase)
+}
+
+// LineCap is the shape at the ends of open stroked paths.
+type LineCap int
+
+const (
+	// ButtCap squares off the stroke at the endpoint, the default.
+	ButtCap LineCap = iota
+	// RoundCap draws a semicircle around the endpoint.
+	RoundCap
+	// SquareCap extends the stroke half the line width past the endpoint.
+	SquareCap
+)
+
+// SetLineCap sets the cap style for subsequent strokes
+func (p *PDFDoc) SetLineCap(c LineCap) {
+	p.linecap = c
+	if p.inpage {
+		fmt.Fprintf(p.Writer, "%d J\n", c)
+	}
+}
+
+// LineJoin is the shape at the corners of stroked paths.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of the strokes until they meet, the default.
+	MiterJoin LineJoin = iota
+	// RoundJoin draws a circular arc around the corner.
+	RoundJoin
+	// BevelJoin cuts the corner off with a straight edge.
+	BevelJoin
+)
+
+// defaultmiterlimit is the PDF default miter limit
+const defaultmiterlimit = 10.0
+
+// SetLineJoin sets the join style for subsequent strokes
+func (p *PDFDoc) SetLineJoin(j LineJoin) {
+	p.linejoin = j
+	if p.inpage {
+		fmt.Fprintf(p.Writer, "%d j\n", j)
+	}
+}
+
+// SetMiterLimit sets the ratio of miter length to line width beyond which
+// miter joins are drawn as bevels, preventing spikes at acute angles
+func (p *PDFDoc) SetMiterLimit(m float64) {
+	if m < 1 {
+		m = 1
+	}
+	p.miterlimit = m
+	if p.inpage {
+		fmt.Fprintf(p.Writer, "%.2f M\n", m)
+	}
+}
+
+// SetOpacity sets the opacity of subsequent fills (including text and images) and strokes,
+// from 0 (transparent) to 1 (opaque)
+func (p *PDFDoc) SetOpacity(opacity float64) {
+	p.SetFillStrokeOpacity(opacity, opacity)
+}
+
+// SetFillStrokeOpacity sets the opacity of fills and strokes independently
+func (p *PDFDoc) SetFillStrokeOpacity(fill, stroke float64) {
+	p.opacity = alpha{fill: unit(fill), stroke: unit(stroke)}
+	if p.inpage {
+		p.writealpha(p.opacity)
+	}
+}
+
+// alpha is a pair of fill and stroke opacities.
+type alpha struct {
+	fill, stroke float64
+}
+
+// opaque is full opacity
+var opaque = alpha{1, 1}
+
+// writealpha sets the opacities in effect on the page
+func (p *PDFDoc) writealpha(a alpha) {
+	fmt.Fprintf(p.Writer, "/%s gs\n", p.extgstates.add("GS", fmt.Sprintf("<< /ca %.3f /CA %.3f >>", a.fill, a.stroke)))
+	p.alpha = a
+}
+
+// fillalpha sets the fill opacity in effect to the opacity setting combined with
+// the alpha of the color, if it is not already
+func (p *PDFDoc) fillalpha(color string) {
+	_, _, _, a := colorlookup(p.rolecolor(color))
+	if want := p.opacity.fill * a; want != p.alpha.fill {
+		p.writealpha(alpha{fill: want, stroke: p.alpha.stroke})
+	}
+}
+
+// strokealpha sets the stroke opacity in effect to the opacity setting combined with
+// the alpha of the color, if it is not already
+func (p *PDFDoc) strokealpha(color string) {
+	_, _, _, a := colorlookup(p.rolecolor(color))
+	if want := p.opacity.stroke * a; want != p.alpha.stroke {
+		p.writealpha(alpha{fill: p.alpha.fill, stroke: want})
+	}
+}
+
+// unit clamps v to the range [0,1]
+func unit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// overprint holds the overprint settings: whether fills and strokes overprint,
+// and the overprint mode.
+type overprint struct {
+	fill, stroke bool
+	mode         int
+}
+
+// SetOverprint sets whether subsequent fills and strokes overprint, rather than knock out,
+// the inks beneath them on other separations
+func (p *PDFDoc) SetOverprint(fill, stroke bool) {
+	p.overprint.fill, p.overprint.stroke = fill, stroke
+	if p.inpage {
+		p.writeoverprint()
+	}
+}
+
+// SetOverprintMode sets the overprint mode: with mode 1, zero components of DeviceCMYK
+// colors leave the inks beneath unchanged; with mode 0 (the default) they erase them
+func (p *PDFDoc) SetOverprintMode(mode int) {
+	if mode != 1 {
+		mode = 0
+	}
+	p.overprint.mode = mode
+	if p.inpage {
+		p.writeoverprint()
+	}
+}
+
+// writeoverprint sets the overprint settings in effect on the page
+func (p *PDFDoc) writeoverprint() {
+	o := p.overprint
+	fmt.Fprintf(p.Writer, "/%s gs\n", p.extgstates.add("GS", fmt.Sprintf("<< /op %t /OP %t /OPM %d >>", o.fill, o.stroke, o.mode)))
+}
+
+// RenderingIntent is how colors are mapped to the gamut of the output device.
+type RenderingIntent int
+
+const (
+	// RelativeColorimetric matches in-gamut colors exactly, relative to the white point, the default.
+	RelativeColorimetric RenderingIntent = iota
+	// AbsoluteColorimetric matches in-gamut colors exactly, including the white point.
+	AbsoluteColorimetric
+	// Perceptual compresses the whole gamut, preserving the relations between colors, for photographs.
+	Perceptual
+	// Saturation preserves saturation, for business graphics.
+	Saturation
+)
+
+var intentnames = [...]string{"RelativeColorimetric", "AbsoluteColorimetric", "Perceptual", "Saturation"}
+
+// String returns the PDF name of the rendering intent
+func (r RenderingIntent) String() string {
+	if r < 0 || int(r) >= len(intentnames) {
+		return intentnames[0]
+	}
+	return intentnames[r]
+}
+
+// SetRenderingIntent sets the rendering intent of subsequent drawing
+func (p *PDFDoc) SetRenderingIntent(r RenderingIntent) {
+	p.intent = r
+	if p.inpage {
+		fmt.Fprintf(p.Writer, "/%s ri\n", r)
+	}
+}
+
+// Push saves the graphics state: transforms, clipping, and stroke and fill settings
+// made until the matching Pop are undone by it. Pushes left unbalanced are popped at EndPage,
+// and reported by validation.
+func (p *PDFDoc) Push() {
+	fmt.Fprintln(p.Writer, "q")
+	p.gstack = append(p.gstack, p.graphicsstate)
+}
+
+// Pop restores the graphics state saved by the most recent Push.
+// A Pop without a matching Push is ignored, and reported by validation.
+func (p *PDFDoc) Pop() {
+	n := len(p.gstack)
+	if n == 0 {
+		p.problem(Unbalanced, p.page, "Pop without a matching Push")
+		return
+	}
+	fmt.Fprintln(p.Writer, "Q")
+	p.graphicsstate = p.gstack[n-1]
+	p.gstack = p.gstack[:n-1]
+}
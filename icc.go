@@ -0,0 +1,58 @@
+package pdfgen
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ICCProfile is an ICC color profile embedded in the document
+type ICCProfile struct {
+	ref int    // object number of the profile stream
+	n   int    // number of color components
+	dev string // device colorspace the profile characterizes: RGB, CMYK, or Gray
+}
+
+// ErrICCProfile is returned for ICC profile data that is not an RGB, CMYK, or gray profile
+var ErrICCProfile = errors.New("pdfgen: unsupported ICC profile")
+
+// EmbedICCProfile embeds the ICC profile data (for example sRGB IEC61966-2.1, or Coated FOGRA39),
+// for use with UseICCProfile and SetOutputIntent. The colorspace of the profile is read from its header.
+func (p *PDFDoc) EmbedICCProfile(data []byte) (ICCProfile, error) {
+	if len(data) < 128 {
+		return ICCProfile{}, ErrICCProfile
+	}
+	var prof ICCProfile
+	switch string(data[16:20]) {
+	case "RGB ":
+		prof.n, prof.dev = 3, "RGB"
+	case "CMYK":
+		prof.n, prof.dev = 4, "CMYK"
+	case "GRAY":
+		prof.n, prof.dev = 1, "Gray"
+	default:
+		return ICCProfile{}, ErrICCProfile
+	}
+	prof.ref = p.addstream(fmt.Sprintf("/N %d /Alternate /Device%s", prof.n, prof.dev), data)
+	return prof, nil
+}
+
+// UseICCProfile makes the profile the ICCBased colorspace of the content: colors and images
+// in the device colorspace the profile characterizes are interpreted using the profile
+func (p *PDFDoc) UseICCProfile(prof ICCProfile) {
+	if prof.ref == 0 {
+		return
+	}
+	p.colorspaces.set("Default"+prof.dev, fmt.Sprintf("[/ICCBased %d 0 R]", prof.ref))
+}
+
+// SetOutputIntent adds the profile as a document output intent, describing the intended
+// output device. subtype is the standard the intent is for, for example "GTS_PDFA1" for PDF/A
+// or "GTS_PDFX" for PDF/X, and condition identifies the output condition, for example "sRGB IEC61966-2.1" or "FOGRA39"
+func (p *PDFDoc) SetOutputIntent(prof ICCProfile, subtype, condition string) {
+	if prof.ref == 0 {
+		return
+	}
+	n := p.addobject(fmt.Sprintf("<< /Type /OutputIntent /S /%s /OutputConditionIdentifier (%s) /Info (%s) /DestOutputProfile %d 0 R >>",
+		pdfname(subtype), pdfstring(condition), pdfstring(condition), prof.ref))
+	p.outputintents = append(p.outputintents, fmt.Sprintf("%d 0 R", n))
+}
@@ -0,0 +1,540 @@
+package pdfgen
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"math"
+)
+
+// ImageOptions are the placement options of ImageGo and ImageFrom.
+type ImageOptions struct {
+	// Width and Height are the placed size. If one is zero, it follows from the other
+	// and the aspect ratio of the image; if both are zero, the image is placed at its natural size,
+	// from its size in pixels and resolution.
+	Width, Height float64
+	// DPI is the resolution of the image, in pixels per inch, for its natural size.
+	// If zero, the resolution recorded in the image file is used, or 72 (one point per pixel) if none is.
+	DPI float64
+	// Source is the region of the image, in pixels, that is embedded and placed; the whole image if empty.
+	Source image.Rectangle
+	// Rotate is the angle, in degrees counterclockwise, by which the image is turned about the center of its box.
+	Rotate float64
+	// FlipH and FlipV mirror the image horizontally and vertically within its box.
+	FlipH, FlipV bool
+	// Alt is the alternate text describing the image, for the Figure in the structure of a tagged document.
+	Alt string
+}
+
+// ImageGo places a Go image (for example one drawn with image/draw) with its lower left at (x,y),
+// without encoding it to a file format first
+func (p *PDFDoc) ImageGo(x, y float64, img image.Image, opts ImageOptions) {
+	img = crop(img, opts.Source)
+	b := img.Bounds()
+	if b.Empty() {
+		return
+	}
+	dpi := opts.DPI
+	if dpi <= 0 {
+		dpi = 72
+	}
+	w, h := opts.size(float64(b.Dx())*72/dpi, float64(b.Dy())*72/dpi)
+	name := p.imageobject(img)
+	p.beginfigure(opts.Alt)
+	p.placematrix(name, opts.matrix(x, y, w, h, 1))
+	p.endfigure(opts.Alt)
+}
+
+// ImageFrom places an image read from r with its lower left at (x,y).
+// Any of the registered image formats may be read.
+func (p *PDFDoc) ImageFrom(x, y float64, r io.Reader, opts ImageOptions) error {
+	info, err := p.readimage(r, opts.Source)
+	if err != nil {
+		return err
+	}
+	xdpi, ydpi := opts.DPI, opts.DPI
+	if xdpi <= 0 {
+		xdpi, ydpi = info.xdpi, info.ydpi
+	}
+	if xdpi <= 0 || ydpi <= 0 {
+		xdpi, ydpi = 72, 72
+	}
+	pw, ph := float64(info.w)*72/xdpi, float64(info.h)*72/ydpi
+	if info.orient >= 5 { // turned a quarter: the placed width is the image height
+		pw, ph = ph, pw
+	}
+	w, h := opts.size(pw, ph)
+	p.beginfigure(opts.Alt)
+	p.placematrix(info.name, opts.matrix(x, y, w, h, info.orient))
+	p.endfigure(opts.Alt)
+	return nil
+}
+
+// FitMode is how ImageFit places an image in a box.
+type FitMode int
+
+const (
+	// Contain scales the image to fit within the box, keeping its aspect ratio, centered.
+	Contain FitMode = iota
+	// Cover scales the image to cover the box, keeping its aspect ratio, centered and clipped to the box.
+	Cover
+	// Stretch scales the image to the box, ignoring its aspect ratio.
+	Stretch
+	// Tile repeats the image at its natural size (one point per pixel) from the top left of the box, clipped to the box.
+	Tile
+)
+
+// ImageFit places an image in the w by h box with its lower left at (x,y), according to the fit mode
+func (p *PDFDoc) ImageFit(x, y, w, h float64, mode FitMode, img image.Image) {
+	b := img.Bounds()
+	if b.Empty() || w <= 0 || h <= 0 {
+		return
+	}
+	name := p.imageobject(img)
+	iw, ih := float64(b.Dx()), float64(b.Dy())
+	switch mode {
+	case Stretch:
+		p.placeimage(x, y, w, h, name)
+	case Tile:
+		p.Push()
+		p.ClipRect(x, y, w, h)
+		for ty := y + h - ih; ty > y-ih; ty -= ih {
+			for tx := x; tx < x+w; tx += iw {
+				p.placeimage(tx, ty, iw, ih, name)
+			}
+		}
+		p.Pop()
+	default:
+		sw, sh := fitsize(iw, ih, w, h, mode == Cover)
+		if mode == Cover {
+			p.Push()
+			p.ClipRect(x, y, w, h)
+		}
+		p.placeimage(x+(w-sw)/2, y+(h-sh)/2, sw, sh, name)
+		if mode == Cover {
+			p.Pop()
+		}
+	}
+}
+
+// fitsize returns the size of an iw by ih image scaled, keeping its aspect ratio,
+// to fit within (or if cover, to cover) a w by h box
+func fitsize(iw, ih, w, h float64, cover bool) (float64, float64) {
+	scale := math.Min(w/iw, h/ih)
+	if cover {
+		scale = math.Max(w/iw, h/ih)
+	}
+	return iw * scale, ih * scale
+}
+
+// beginfigure begins the content of an image with alternate text
+func (p *PDFDoc) beginfigure(alt string) {
+	if alt != "" {
+		p.beginmarked("Figure", alt)
+	}
+}
+
+// endfigure ends the content of an image with alternate text
+func (p *PDFDoc) endfigure(alt string) {
+	if alt != "" {
+		p.endmarked()
+	}
+}
+
+// Caption typesets a numbered caption ("Figure 1. s") wrapped to width, with its first line
+// below y, as under an image. It returns the y below the caption.
+func (p *PDFDoc) Caption(x, y, width float64, s, font string, size float64, color string) float64 {
+	p.figures++
+	p.beginmarked("Caption", "")
+	for _, line := range WrapText(fmt.Sprintf("Figure %d. %s", p.figures, s), font, size, width) {
+		y -= size * 1.2
+		p.Text(x, y, line, font, size, color)
+	}
+	p.endmarked()
+	return y - size*0.4
+}
+
+// crop returns the part of an image within the source rectangle,
+// or the image if the rectangle is empty
+func crop(img image.Image, src image.Rectangle) image.Image {
+	if src.Empty() {
+		return img
+	}
+	if s, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return s.SubImage(src)
+	}
+	return img
+}
+
+// BilevelImage places an image reduced to black and white, with its lower left at (x,y) and scaled to w by h,
+// compressed with CCITT Group 4 as for scanned documents. Pixels darker than middle gray are black.
+func (p *PDFDoc) BilevelImage(x, y, w, h float64, img image.Image) {
+	if img.Bounds().Empty() {
+		return
+	}
+	p.placeimage(x, y, w, h, p.bilevelobject(img, ""))
+}
+
+// DrawImageMask paints the current fill color through an image used as a stencil,
+// with its lower left at (x,y) and scaled to w by h: pixels darker than middle gray are painted,
+// lighter or transparent pixels leave the page as it is
+func (p *PDFDoc) DrawImageMask(x, y, w, h float64, img image.Image) {
+	if img.Bounds().Empty() || !visible(p.pen.fill) {
+		return
+	}
+	p.fillalpha(p.pen.fill)
+	p.writeop(&p.written.fill, p.coloroperator(p.pen.fill, false))
+	p.placeimage(x, y, w, h, p.bilevelobject(img, "/ImageMask true"))
+}
+
+// bilevelobject returns the resource name of an image XObject holding the bilevel
+// image with CCITT Group 4 compression: a DeviceGray image, or a stencil mask.
+// In both, coded black pixels are zero: black in DeviceGray, and painted in a mask.
+func (p *PDFDoc) bilevelobject(img image.Image, mask string) string {
+	b := img.Bounds()
+	if mask == "" {
+		mask = "/ColorSpace /DeviceGray"
+	}
+	dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d %s /BitsPerComponent 1 /Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns %d /Rows %d >>",
+		b.Dx(), b.Dy(), mask, b.Dx(), b.Dy())
+	rows := bilevel(img)
+	return p.imageresource(digest(append([][]byte{[]byte(dict)}, rows...)...), func() int {
+		return p.addstream(dict, encodeG4(rows))
+	})
+}
+
+// size returns the placed size of an image of natural size pw by ph
+func (o ImageOptions) size(pw, ph float64) (float64, float64) {
+	switch {
+	case o.Width > 0 && o.Height > 0:
+		return o.Width, o.Height
+	case o.Width > 0:
+		return o.Width, o.Width * ph / pw
+	case o.Height > 0:
+		return o.Height * pw / ph, o.Height
+	}
+	return pw, ph
+}
+
+// imageinfo describes an image read for placement.
+type imageinfo struct {
+	name       string  // resource name of the image XObject
+	w, h       int     // size in pixels
+	orient     int     // EXIF orientation
+	xdpi, ydpi float64 // resolution recorded in the image, zero if none
+}
+
+// readimage reads an image, returning the resource name of its XObject, its size in pixels,
+// orientation (see SetImageOrientation), and resolution.
+// JPEG data is embedded as it is, without decoding, unless only the part of the image
+// within the source rectangle is wanted; other formats are decoded.
+func (p *PDFDoc) readimage(r io.Reader, src image.Rectangle) (imageinfo, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return imageinfo{}, err
+	}
+	info := imageinfo{orient: 1}
+	if !p.ignoreexif {
+		info.orient = jpegorientation(data)
+	}
+	info.xdpi, info.ydpi = imagedpi(data)
+	if src.Empty() {
+		if name, w, h, ok := p.jpegobject(data); ok {
+			info.name, info.w, info.h = name, w, h
+			return info, nil
+		}
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return imageinfo{}, err
+	}
+	img = crop(img, src)
+	b := img.Bounds()
+	if b.Empty() {
+		return imageinfo{}, fmt.Errorf("pdfgen: source %v outside the image bounds", src)
+	}
+	info.name, info.w, info.h = p.imageobject(img), b.Dx(), b.Dy()
+	return info, nil
+}
+
+// imagedpi returns the horizontal and vertical resolution, in dots per inch, recorded in
+// JPEG (JFIF) or PNG (pHYs) data, or zero if none is recorded
+func imagedpi(data []byte) (float64, float64) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		if seg := jpegsegment(data, 0xe0, "JFIF\x00"); len(seg) >= 12 {
+			x, y := float64(binary.BigEndian.Uint16(seg[8:])), float64(binary.BigEndian.Uint16(seg[10:]))
+			switch seg[7] {
+			case 1: // dots per inch
+				return x, y
+			case 2: // dots per cm
+				return x * 2.54, y * 2.54
+			}
+		}
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		for i := 8; i+8 <= len(data); {
+			n := int(binary.BigEndian.Uint32(data[i:]))
+			chunk := string(data[i+4 : i+8])
+			if chunk == "IDAT" || i+8+n > len(data) {
+				break
+			}
+			if c := data[i+8 : i+8+n]; chunk == "pHYs" && n >= 9 && c[8] == 1 { // pixels per meter
+				return float64(binary.BigEndian.Uint32(c)) * 0.0254, float64(binary.BigEndian.Uint32(c[4:])) * 0.0254
+			}
+			i += 12 + n
+		}
+	}
+	return 0, 0
+}
+
+// jpegobject returns the resource name of an image XObject holding JPEG data
+// with the DCTDecode filter, its size in pixels, and whether the data is a JPEG image that can be embedded so.
+// CMYK (and YCCK) JPEGs are embedded in DeviceCMYK if SetImageCMYK is set, and otherwise decoded and converted to RGB.
+func (p *PDFDoc) jpegobject(data []byte) (string, int, int, bool) {
+	if !bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff}) {
+		return "", 0, 0, false
+	}
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, false
+	}
+	var cs string
+	switch config.ColorModel {
+	case color.GrayModel:
+		cs = "/DeviceGray"
+	case color.YCbCrModel:
+		if p.colormode == GrayMode {
+			return "", 0, 0, false
+		}
+		cs = "/DeviceRGB"
+	case color.CMYKModel:
+		if !p.imagecmyk || p.colormode == GrayMode {
+			return "", 0, 0, false
+		}
+		cs = "/DeviceCMYK"
+		if jpegsegment(data, 0xee, "Adobe") != nil { // Adobe applications write inverted CMYK
+			cs += " /Decode [1 0 1 0 1 0 1 0]"
+		}
+	default:
+		return "", 0, 0, false
+	}
+	dict := fmt.Sprintf(imagefmt, config.Width, config.Height, cs) + " /Filter /DCTDecode"
+	name := p.imageresource(digest([]byte(dict), data), func() int { return p.addstream(dict, data) })
+	return name, config.Width, config.Height, true
+}
+
+// placeoriented places the image XObject with the resource name, turned to the orientation
+// (an EXIF orientation value), in the w by h box with its lower left at (x,y)
+func (p *PDFDoc) placeoriented(x, y, w, h float64, name string, orient int) {
+	p.placematrix(name, ImageOptions{}.matrix(x, y, w, h, orient))
+}
+
+// placematrix places the image XObject with the resource name, transforming its unit square by m
+func (p *PDFDoc) placematrix(name string, m matrix) {
+	fmt.Fprintf(p.Writer, "q "+cmfmt+"/%s Do Q\n", m[0], m[1], m[2], m[3], m[4], m[5], name)
+}
+
+// matrix returns the matrix placing the image unit square in the w by h box with its lower
+// left at (x,y): turned to the orientation (an EXIF orientation value), flipped, and rotated
+func (o ImageOptions) matrix(x, y, w, h float64, orient int) matrix {
+	if orient < 1 || orient >= len(orientations) {
+		orient = 1
+	}
+	m := orientations[orient]
+	if o.FlipH {
+		m = m.multiply(matrix{-1, 0, 0, 1, 1, 0})
+	}
+	if o.FlipV {
+		m = m.multiply(matrix{1, 0, 0, -1, 0, 1})
+	}
+	m = m.multiply(matrix{w, 0, 0, h, 0, 0})
+	if o.Rotate != 0 {
+		a := o.Rotate * (math.Pi / 180)
+		sin, cos := math.Sin(a), math.Cos(a)
+		cx, cy := w/2, h/2
+		m = m.multiply(matrix{cos, sin, -sin, cos, cx - cx*cos + cy*sin, cy - cx*sin - cy*cos})
+	}
+	return m.multiply(matrix{1, 0, 0, 1, x, y})
+}
+
+// imageobject returns the resource name of an image XObject holding the image.
+// Images are stored once per document: placing an image with the same pixels again
+// refers to the same XObject, without encoding the image again.
+func (p *PDFDoc) imageobject(img image.Image) string {
+	b := img.Bounds()
+	cs := "/DeviceRGB"
+	if _, ok := img.(*image.CMYK); ok && p.imagecmyk {
+		cs = "/DeviceCMYK"
+	}
+	if p.colormode == GrayMode || grayimage(img) {
+		cs = "/DeviceGray"
+	}
+	dict := fmt.Sprintf(imagefmt, b.Dx(), b.Dy(), cs)
+	return p.imageresource(p.imagekey(dict, img), func() int {
+		if o, ok := img.(interface{ Opaque() bool }); ok && !o.Opaque() {
+			dict += fmt.Sprintf(" /SMask %d 0 R", p.softmaskobject(img))
+		}
+		colors := map[string]int{"/DeviceRGB": 3, "/DeviceCMYK": 4, "/DeviceGray": 1}[cs]
+		return p.imagestream(dict, colors, b.Dx(), func(w io.Writer) error {
+			switch cs {
+			case "/DeviceCMYK":
+				return encodeCMYKStream(w, img.(*image.CMYK))
+			case "/DeviceGray":
+				return encodeGrayStream(w, img)
+			}
+			return imagedata(w, img)
+		})
+	})
+}
+
+// grayimage reports whether an image holds only shades of gray: gray images,
+// images with a gray palette, and YCbCr images (such as JPEGs) without chroma
+func grayimage(img image.Image) bool {
+	switch i := img.(type) {
+	case *image.Gray, *image.Gray16:
+		return true
+	case *image.Paletted:
+		for _, c := range i.Palette {
+			r, g, b, _ := c.RGBA()
+			if r != g || g != b {
+				return false
+			}
+		}
+		return true
+	case *image.YCbCr:
+		for _, c := range [][]uint8{i.Cb, i.Cr} {
+			for _, v := range c {
+				if v != 128 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// softmaskobject returns the object number of a grayscale image of
+// the alpha channel of an image, for use as its soft mask
+func (p *PDFDoc) softmaskobject(img image.Image) int {
+	b := img.Bounds()
+	dict := fmt.Sprintf(imagefmt, b.Dx(), b.Dy(), "/DeviceGray")
+	return p.imageref(p.imagekey("alpha "+dict, img), func() int {
+		return p.imagestream(dict, 1, b.Dx(), func(w io.Writer) error {
+			return encodeAlphaStream(w, img)
+		})
+	})
+}
+
+// encodeAlphaStream writes the alpha channel of an image, one byte per pixel
+func encodeAlphaStream(w io.Writer, img image.Image) error {
+	bd := img.Bounds()
+	row := getrow(bd.Dx())
+	defer putrow(row)
+	for y := bd.Min.Y; y < bd.Max.Y; y++ {
+		for x := bd.Min.X; x < bd.Max.X; x++ {
+			_, _, _, a := img.At(x, y).RGBA()
+			row[x-bd.Min.X] = uint8(a >> 8)
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetImageCompression sets the zlib compression level (see compress/flate) of the data of
+// subsequent images other than JPEGs; flate.NoCompression writes the pixels as they are.
+// The default is flate.DefaultCompression.
+func (p *PDFDoc) SetImageCompression(level int) {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		level = flate.DefaultCompression
+	}
+	p.imagelevel = level
+}
+
+// SetImagePredictor sets whether compressed image rows are first encoded as differences
+// from the row above (the PNG Up predictor), which often compresses photographs better
+func (p *PDFDoc) SetImagePredictor(predict bool) {
+	p.imagepredict = predict
+}
+
+// imageresource returns the resource name of the image XObject identified by key, as imageref does
+func (p *PDFDoc) imageresource(key string, add func() int) string {
+	return p.xobjects.add("Im", fmt.Sprintf("%d 0 R", p.imageref(key, add)))
+}
+
+// imageref returns the object number of the image XObject identified by key, a digest of
+// its source data and how it is encoded, calling add to add the XObject unless it was added before
+func (p *PDFDoc) imageref(key string, add func() int) int {
+	if ref, ok := p.images[key]; ok {
+		p.imagereuses++
+		return ref
+	}
+	if p.images == nil {
+		p.images = make(map[string]int)
+	}
+	ref := add()
+	p.images[key] = ref
+	return ref
+}
+
+// imagekey returns the key of the XObject made of an image: a digest of its pixels, with kind
+// (the dictionary entries of the XObject) and the compression settings. The image is not encoded.
+func (p *PDFDoc) imagekey(kind string, img image.Image) string {
+	h := sha256.New()
+	b := img.Bounds()
+	fmt.Fprintf(h, "%s %T %v %d %t\n", kind, img, b.Size(), p.imagelevel, p.imagepredict)
+	switch i := img.(type) {
+	case *image.RGBA:
+		pixrows(h, i.Pix[i.PixOffset(b.Min.X, b.Min.Y):], i.Stride, 4*b.Dx(), b.Dy())
+	case *image.NRGBA:
+		pixrows(h, i.Pix[i.PixOffset(b.Min.X, b.Min.Y):], i.Stride, 4*b.Dx(), b.Dy())
+	case *image.CMYK:
+		pixrows(h, i.Pix[i.PixOffset(b.Min.X, b.Min.Y):], i.Stride, 4*b.Dx(), b.Dy())
+	case *image.Gray:
+		pixrows(h, i.Pix[i.PixOffset(b.Min.X, b.Min.Y):], i.Stride, b.Dx(), b.Dy())
+	case *image.Paletted:
+		fmt.Fprintln(h, i.Palette)
+		pixrows(h, i.Pix[i.PixOffset(b.Min.X, b.Min.Y):], i.Stride, b.Dx(), b.Dy())
+	default:
+		row := getrow(8 * b.Dx())
+		defer putrow(row)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, g, bl, a := img.At(x, y).RGBA()
+				o := 8 * (x - b.Min.X)
+				binary.BigEndian.PutUint16(row[o:], uint16(r))
+				binary.BigEndian.PutUint16(row[o+2:], uint16(g))
+				binary.BigEndian.PutUint16(row[o+4:], uint16(bl))
+				binary.BigEndian.PutUint16(row[o+6:], uint16(a))
+			}
+			h.Write(row)
+		}
+	}
+	return string(h.Sum(nil))
+}
+
+// pixrows writes n rows of w bytes of pixel data, stride bytes apart, to h
+func pixrows(h io.Writer, pix []byte, stride, w, n int) {
+	for y := 0; y < n; y++ {
+		h.Write(pix[y*stride : y*stride+w])
+	}
+}
+
+// digest returns the key of an XObject made of data as it is, without encoding
+func digest(data ...[]byte) string {
+	h := sha256.New()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return string(h.Sum(nil))
+}
@@ -0,0 +1,103 @@
+package pdfgen
+
+import (
+	"compress/flate"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Image data is encoded a row at a time, through the optional predictor and compression,
+// and written as it is encoded, so that the pixels of an image are not held in memory.
+// Nor is its compressed data, except for images added while a page is drawn: their objects
+// are held until the page ends, since they cannot be written into its content.
+
+// rowpool holds row buffers for reuse by the image encoders
+var rowpool sync.Pool
+
+// getrow returns a row buffer of n bytes
+func getrow(n int) []byte {
+	if b, ok := rowpool.Get().(*[]byte); ok && cap(*b) >= n {
+		return (*b)[:n]
+	}
+	return make([]byte, n)
+}
+
+// putrow returns a row buffer to the pool
+func putrow(b []byte) {
+	rowpool.Put(&b)
+}
+
+// imagestream adds an image XObject with the dictionary entries in dict and the pixels
+// written by encode, with the given number of color components and columns, compressed per
+// SetImageCompression and SetImagePredictor, returning its object number
+func (p *PDFDoc) imagestream(dict string, colors, columns int, encode func(io.Writer) error) int {
+	if p.imagelevel == flate.NoCompression {
+		return p.writestream(dict, encode)
+	}
+	dict += " /Filter /FlateDecode"
+	if p.imagepredict {
+		dict += fmt.Sprintf(" /DecodeParms << /Predictor 12 /Colors %d /BitsPerComponent 8 /Columns %d >>", colors, columns)
+	}
+	return p.writestream(dict, func(w io.Writer) error {
+		zw, _ := zlib.NewWriterLevel(w, p.imagelevel)
+		var dst io.Writer = zw
+		if p.imagepredict {
+			pw := newpredictor(zw, colors*columns)
+			defer pw.release()
+			dst = pw
+		}
+		if err := encode(dst); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+}
+
+// predictor encodes rows of pixel data with the PNG Up filter: each row, prefixed with
+// the filter type, holds the differences from the row above.
+type predictor struct {
+	w         io.Writer
+	prev, cur []byte // the row above, and the row being filled
+	n         int    // bytes in cur
+	out       []byte // a filtered row: the filter type, then the differences
+}
+
+// newpredictor returns a predictor of rows of n bytes, writing to w
+func newpredictor(w io.Writer, n int) *predictor {
+	pw := &predictor{w: w, prev: getrow(n), cur: getrow(n), out: getrow(n + 1)}
+	for i := range pw.prev {
+		pw.prev[i] = 0
+	}
+	return pw
+}
+
+// Write takes pixel data, writing each row as it is completed
+func (pw *predictor) Write(b []byte) (int, error) {
+	written := len(b)
+	for len(b) > 0 && len(pw.cur) > 0 {
+		c := copy(pw.cur[pw.n:], b)
+		pw.n += c
+		b = b[c:]
+		if pw.n < len(pw.cur) {
+			break
+		}
+		pw.out[0] = 2
+		for i, v := range pw.cur {
+			pw.out[i+1] = v - pw.prev[i]
+		}
+		if _, err := pw.w.Write(pw.out); err != nil {
+			return written - len(b), err
+		}
+		pw.prev, pw.cur, pw.n = pw.cur, pw.prev, 0
+	}
+	return written, nil
+}
+
+// release returns the row buffers of the predictor to the pool
+func (pw *predictor) release() {
+	putrow(pw.prev)
+	putrow(pw.cur)
+	putrow(pw.out)
+}
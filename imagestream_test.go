@@ -0,0 +1,120 @@
+package pdfgen
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// streamobject returns the data of the stream object n, checking that its length object
+// gives its size
+func streamobject(t *testing.T, pdf []byte, n int) (string, []byte) {
+	t.Helper()
+	re := regexp.MustCompile(`(?m)^` + strconv.Itoa(n) + ` 0 obj\n<< (.*) /Length (\d+) 0 R >>\nstream\n`)
+	m := re.FindSubmatchIndex(pdf)
+	if m == nil {
+		t.Fatalf("no stream object %d", n)
+	}
+	dict, lengthobj := string(pdf[m[2]:m[3]]), string(pdf[m[4]:m[5]])
+	lm := regexp.MustCompile(`(?m)^` + lengthobj + ` 0 obj\n(\d+)\nendobj`).FindSubmatch(pdf)
+	if lm == nil {
+		t.Fatalf("no length object %s for stream %d", lengthobj, n)
+	}
+	length, _ := strconv.Atoi(string(lm[1]))
+	end := m[1] + length
+	if end > len(pdf) || !bytes.HasPrefix(pdf[end:], []byte("\nendstream")) {
+		t.Fatalf("stream %d: length %d does not end at endstream", n, length)
+	}
+	return dict, pdf[m[1]:end]
+}
+
+// decodestream undoes the compression and predictor of image data with rows of n bytes
+func decodestream(t *testing.T, data []byte, n int, compressed, predicted bool) []byte {
+	t.Helper()
+	if !compressed {
+		return data
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !predicted {
+		return b
+	}
+	var out []byte
+	prev := make([]byte, n)
+	for len(b) > 0 {
+		if len(b) < n+1 || b[0] != 2 {
+			t.Fatalf("row is not Up predicted: %d bytes left, filter %d", len(b), b[0])
+		}
+		row := make([]byte, n)
+		for i := range row {
+			row[i] = b[1+i] + prev[i]
+		}
+		out, prev, b = append(out, row...), row, b[n+1:]
+	}
+	return out
+}
+
+func TestImageStream(t *testing.T) {
+	const w, h = 37, 23
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(7 * x), uint8(11 * y), uint8(x * y), uint8(255 - 3*x)})
+		}
+	}
+	var rgb, alpha []byte
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.NRGBAAt(x, y)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+		}
+	}
+	tests := []struct {
+		name    string
+		level   int
+		predict bool
+	}{
+		{"compressed", flate.DefaultCompression, false},
+		{"predicted", flate.BestSpeed, true},
+		{"uncompressed", flate.NoCompression, false},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		doc := NewDoc(&buf, 200, 200)
+		doc.SetImageCompression(tt.level)
+		doc.SetImagePredictor(tt.predict)
+		doc.Init(1)
+		doc.NewPage(1)
+		doc.ImageGo(10, 10, img, ImageOptions{})
+		doc.EndPage()
+		if err := doc.EndDoc(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		compressed := tt.level != flate.NoCompression
+		// the soft mask is added first, then the image, each followed by its length
+		maskdict, maskdata := streamobject(t, buf.Bytes(), 5)
+		imgdict, imgdata := streamobject(t, buf.Bytes(), 7)
+		if !bytes.Contains([]byte(imgdict), []byte("/SMask 5 0 R")) || !bytes.Contains([]byte(maskdict), []byte("/DeviceGray")) {
+			t.Fatalf("%s: image %q, mask %q", tt.name, imgdict, maskdict)
+		}
+		if got := decodestream(t, imgdata, 3*w, compressed, tt.predict); !bytes.Equal(got, rgb) {
+			t.Errorf("%s: image pixels differ", tt.name)
+		}
+		if got := decodestream(t, maskdata, w, compressed, tt.predict); !bytes.Equal(got, alpha) {
+			t.Errorf("%s: soft mask pixels differ", tt.name)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build tiff
+// +build tiff
+
+package pdfgen
+
+// Building with the tiff tag adds TIFF to the formats read by Image and ImageReader.
+import _ "golang.org/x/image/tiff"
@@ -0,0 +1,7 @@
+//go:build webp
+// +build webp
+
+package pdfgen
+
+// Building with the webp tag adds WebP to the formats read by Image and ImageReader.
+import _ "golang.org/x/image/webp"
@@ -0,0 +1,87 @@
+package pdfgen
+
+import "io"
+
+// Layout describes a document as computed by a measuring pass.
+type Layout struct {
+	Pages   int   // number of pages begun with NewPage
+	Bytes   int64 // size of the generated output
+	Objects int   // number of PDF objects
+
+	// The size of each page begun, in order: the bytes and objects of the page and its content,
+	// and of the objects (images, patterns, etc.) first added while it was drawn
+	PageBytes   []int64
+	PageObjects []int
+}
+
+// pagesize is the size of a page, as recorded by a measuring document
+type pagesize struct {
+	bytes   int64
+	objects int
+}
+
+// countwriter is a measuring sink: it counts, then discards, the bytes written to it.
+type countwriter struct {
+	n int64
+}
+
+func (c *countwriter) Write(b []byte) (int, error) {
+	c.n += int64(len(b))
+	return len(b), nil
+}
+
+// Measure runs render against a measuring sink, and returns the resulting layout.
+// No bytes are written; the Layout passed to render is the zero value.
+func Measure(pagewidth, pageheight float64, render func(*PDFDoc, Layout)) Layout {
+	cw := &countwriter{}
+	doc := NewDoc(cw, pagewidth, pageheight)
+	doc.measuring = true
+	doc.counting = true
+	render(doc, Layout{})
+	return doc.layout()
+}
+
+// Render makes two passes: a layout pass that measures the document,
+// followed by a render pass to w. On the render pass, render receives the
+// measured Layout, so that page counts ("Page N of M"), table of contents
+// page numbers, and balanced columns are known before any bytes are written.
+// The layout of the render pass is returned.
+func Render(w io.Writer, pagewidth, pageheight float64, render func(*PDFDoc, Layout)) Layout {
+	l := Measure(pagewidth, pageheight, render)
+	doc := NewDoc(w, pagewidth, pageheight)
+	doc.counting = true
+	render(doc, l)
+	return doc.layout()
+}
+
+// layout returns the layout of a document whose pages were counted
+func (p *PDFDoc) layout() Layout {
+	l := Layout{Pages: p.pagecount, Bytes: p.out.n, Objects: p.objectcount}
+	for _, ps := range p.pagesizes {
+		l.PageBytes = append(l.PageBytes, ps.bytes)
+		l.PageObjects = append(l.PageObjects, ps.objects)
+	}
+	return l
+}
+
+// pagestart notes the size of the document as a page begins, if it is being counted
+func (p *PDFDoc) pagestart() {
+	if p.counting {
+		p.pagemark = pagesize{bytes: p.out.n + int64(p.objects.Len()), objects: p.objectcount}
+	}
+}
+
+// pageend records the size of the page that ends, if the document is being counted
+func (p *PDFDoc) pageend() {
+	if p.counting {
+		p.pagesizes = append(p.pagesizes, pagesize{
+			bytes:   p.out.n + int64(p.objects.Len()) - p.pagemark.bytes,
+			objects: p.objectcount - p.pagemark.objects,
+		})
+	}
+}
+
+// Measuring reports whether the document is being rendered to a measuring sink.
+func (p *PDFDoc) Measuring() bool {
+	return p.measuring
+}
@@ -0,0 +1,63 @@
+package pdfgen
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"testing"
+)
+
+func TestMeasureRender(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	logo := image.NewGray(image.Rect(0, 0, 8, 8))
+	logo.SetGray(3, 3, color.Gray{Y: 200})
+	draw := func(doc *PDFDoc, l Layout) {
+		const pages = 4
+		doc.Init(pages)
+		for n := 1; n <= pages; n++ {
+			doc.NewPage(n)
+			doc.Text(72, 720, fmt.Sprintf("Page %d of %d", n, l.Pages), "sans", 12, "black")
+			doc.ImageGo(72, 72, logo, ImageOptions{}) // the same image on every page
+			for i := 0; i < n; i++ {
+				doc.Circle(100+float64(20*i), 400, 8, "steelblue")
+			}
+			if n%2 == 0 {
+				doc.ImageGo(200, 200, img, ImageOptions{})
+				doc.GradientRect(300, 300, 50, 50, LinearGradient(300, 0, 350, 0, []GradientStop{{0, "red"}, {1, "blue/50"}}))
+			}
+			doc.EndPage()
+		}
+		doc.EndDoc()
+	}
+	measured := Measure(612, 792, draw)
+	var buf bytes.Buffer
+	rendered := Render(&buf, 612, 792, draw)
+
+	if measured.Pages != 4 || rendered.Pages != 4 {
+		t.Errorf("pages: measured %d, rendered %d; want 4", measured.Pages, rendered.Pages)
+	}
+	if rendered.Bytes != int64(buf.Len()) || measured.Bytes != rendered.Bytes {
+		t.Errorf("bytes: measured %d, rendered %d; %d written", measured.Bytes, rendered.Bytes, buf.Len())
+	}
+	objects := len(regexp.MustCompile(`(?m)^\d+ 0 obj$`).FindAll(buf.Bytes(), -1))
+	if measured.Objects != rendered.Objects || rendered.Objects != objects {
+		t.Errorf("objects: measured %d, rendered %d; %d written", measured.Objects, rendered.Objects, objects)
+	}
+	if len(measured.PageBytes) != 4 || len(rendered.PageBytes) != 4 || len(measured.PageObjects) != 4 || len(rendered.PageObjects) != 4 {
+		t.Fatalf("page sizes: measured %v %v, rendered %v %v", measured.PageBytes, measured.PageObjects, rendered.PageBytes, rendered.PageObjects)
+	}
+	for i := 0; i < 4; i++ {
+		if measured.PageBytes[i] != rendered.PageBytes[i] || measured.PageObjects[i] != rendered.PageObjects[i] {
+			t.Errorf("page %d: measured %d bytes, %d objects; rendered %d bytes, %d objects",
+				i+1, measured.PageBytes[i], measured.PageObjects[i], rendered.PageBytes[i], rendered.PageObjects[i])
+		}
+	}
+	if rendered.PageObjects[0] <= rendered.PageObjects[2] || rendered.PageObjects[1] <= rendered.PageObjects[3] {
+		t.Errorf("page objects %v: the first page with an image should hold it", rendered.PageObjects)
+	}
+}
@@ -0,0 +1,50 @@
+package pdfgen
+
+// Marker is a plot symbol.
+type Marker int
+
+const (
+	CircleMarker Marker = iota
+	SquareMarker
+	TriangleMarker
+	DiamondMarker
+	PlusMarker
+	CrossMarker
+	StarMarker
+)
+
+// Markers draws the marker symbol at each (x,y) point, size units across, in the specified color
+func (p *PDFDoc) Markers(x []float64, y []float64, m Marker, size float64, color string) {
+	if len(x) != len(y) {
+		return
+	}
+	for i := range x {
+		p.marker(x[i], y[i], m, size, color)
+	}
+}
+
+// marker draws a single marker centered at (x,y)
+func (p *PDFDoc) marker(x, y float64, m Marker, size float64, color string) {
+	r := size / 2
+	sw := size / 5
+	switch m {
+	case CircleMarker:
+		p.ellipsepath(x, y, r, r)
+		p.FillPath(color)
+	case SquareMarker:
+		p.Rect(x-r, y-r, size, size, color)
+	case TriangleMarker:
+		p.RegularPolygon(x, y, r, 3, 0, color)
+	case DiamondMarker:
+		p.RegularPolygon(x, y, r, 4, 0, color)
+	case PlusMarker:
+		p.Line(x-r, y, x+r, y, sw, color)
+		p.Line(x, y-r, x, y+r, sw, color)
+	case CrossMarker:
+		d := r * 0.7071
+		p.Line(x-d, y-d, x+d, y+d, sw, color)
+		p.Line(x-d, y+d, x+d, y-d, sw, color)
+	case StarMarker:
+		p.Star(x, y, r, r*0.4, 5, 0, color)
+	}
+}
@@ -0,0 +1,38 @@
+package pdfgen
+
+import "fmt"
+
+// SoftMask applies a luminosity soft mask to subsequent drawing on the page.
+// The mask is drawn by draw: where the mask is white, drawing is opaque; where it is black,
+// or not drawn, drawing is transparent; grays are in between. Gradients make gradual fades.
+// Scope the mask with Push and Pop, or remove it with ClearSoftMask.
+func (p *PDFDoc) SoftMask(draw func(*PDFDoc)) {
+	content := p.capture(draw)
+	form := p.addstream(fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [%.2f %.2f %.2f %.2f] /Group << /S /Transparency /CS /DeviceGray >> /Resources 2 0 R",
+		-p.width, -p.height, 2*p.width, 2*p.height), content)
+	p.setsoftmask(p.extgstates.add("GS", fmt.Sprintf("<< /SMask << /Type /Mask /S /Luminosity /G %d 0 R >> >>", form)))
+}
+
+// FadeMask applies a soft mask that fades subsequent drawing linearly,
+// from opacity from at (x1,y1) to opacity to at (x2,y2)
+func (p *PDFDoc) FadeMask(x1, y1, x2, y2, from, to float64) {
+	sh := p.shadings.add("Sh", fmt.Sprintf("<< /ShadingType 2 /ColorSpace /DeviceGray /Coords [%.2f %.2f %.2f %.2f] /Function << /FunctionType 2 /Domain [0 1] /C0 [%.3f] /C1 [%.3f] /N 1 >> /Extend [true true] >>",
+		x1, y1, x2, y2, unit(from), unit(to)))
+	p.SoftMask(func(d *PDFDoc) {
+		fmt.Fprintf(d.Writer, "/%s sh\n", sh)
+	})
+}
+
+// ClearSoftMask removes the soft mask
+func (p *PDFDoc) ClearSoftMask() {
+	p.setsoftmask(p.extgstates.add("GS", "<< /SMask /None >>"))
+	p.softmask = ""
+}
+
+// setsoftmask makes the named graphics state the current soft mask
+func (p *PDFDoc) setsoftmask(name string) {
+	p.softmask = name
+	if p.inpage {
+		fmt.Fprintf(p.Writer, "/%s gs\n", name)
+	}
+}
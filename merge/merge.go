@@ -0,0 +1,376 @@
+// Package merge fills page templates with records of data, to make certificates,
+// badges, invoices, and the like: a page for each record, or a document for each.
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+	"github.com/ajstarks/pdfgen/table"
+)
+
+// Record is the data merged into a template, by field name. Values may be nested records,
+// reached by dotted names such as "customer.name".
+type Record map[string]interface{}
+
+// Records converts a struct, map, or slice of them into records, through their JSON encoding;
+// the field names are those of the JSON encoding
+func Records(v interface{}) ([]Record, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(b)
+}
+
+// ReadJSON reads records from a JSON array of objects, or a single object
+func ReadJSON(r io.Reader) ([]Record, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	return decode(raw)
+}
+
+// decode decodes a JSON array of objects, or a single object, keeping numbers as written
+func decode(b []byte) ([]Record, error) {
+	var records []Record
+	if err := unmarshal(b, &records); err == nil {
+		return records, nil
+	}
+	var r Record
+	if err := unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("merge: records must be objects: %v", err)
+	}
+	return []Record{r}, nil
+}
+
+// unmarshal decodes JSON into v, with numbers as json.Number
+func unmarshal(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// Value returns the value of a name, which may be dotted to reach into nested records
+func (r Record) Value(name string) (interface{}, bool) {
+	var v interface{} = map[string]interface{}(r)
+	for _, part := range strings.Split(name, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			if rec, isrec := v.(Record); isrec {
+				m, ok = rec, true
+			}
+		}
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// String returns the value of a name as text, or "" if it has none
+func (r Record) String(name string) string {
+	v, ok := r.Value(name)
+	if !ok {
+		return ""
+	}
+	return text(v)
+}
+
+// text returns a value as text: numbers in full rather than in exponent form, nil as ""
+func text(v interface{}) string {
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32)
+	}
+	return fmt.Sprint(v)
+}
+
+// Expand replaces the {name} placeholders in s with the record's values
+func (r Record) Expand(s string) string {
+	var b strings.Builder
+	for {
+		open := strings.Index(s, "{")
+		if open < 0 {
+			break
+		}
+		close := strings.Index(s[open:], "}")
+		if close < 0 {
+			break
+		}
+		b.WriteString(s[:open])
+		b.WriteString(r.String(strings.TrimSpace(s[open+1 : open+close])))
+		s = s[open+close+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// Kind is the kind of content of a field.
+type Kind int
+
+const (
+	// Text is text, wrapped to the width of the field, and shrunk if need be to fit its height.
+	Text Kind = iota
+	// Image is an image, named by the value, fit within the field.
+	Image
+	// Table is a table of the value's rows of cells, from the top of the field.
+	Table
+)
+
+// Field is a region of a template filled from a record.
+type Field struct {
+	Kind       Kind
+	Name       string  // name of the record's value
+	Text       string  // text of a text field, with {name} placeholders; if empty, the value of Name
+	X, Y, W, H float64 // the field's box, with its lower left at (x,y)
+	Font       string  // font of text, default sans
+	Size       float64 // size of text, default 12
+	Color      string  // color of text, default black
+	Align      table.Align
+
+	Columns []table.Column // columns of a table; if empty, equal columns filling the width
+	Header  bool           // the first row of a table is a header
+	Style   table.Style    // style of a table
+}
+
+// Template is a page layout with fields to be filled from records.
+type Template struct {
+	Width, Height float64              // page size, default 612 by 792
+	Static        func(*pdfgen.PDFDoc) // draws the fixed content of each page, beneath the fields, if not nil
+	Fields        []Field              // fields, drawn in order
+}
+
+// size returns the page size
+func (t Template) size() (float64, float64) {
+	w, h := t.Width, t.Height
+	if w <= 0 {
+		w = 612
+	}
+	if h <= 0 {
+		h = 792
+	}
+	return w, h
+}
+
+// Merge writes a document of a page for each record
+func (t Template) Merge(w io.Writer, records []Record) error {
+	pw, ph := t.size()
+	doc := pdfgen.NewDoc(w, pw, ph)
+	doc.Init(len(records))
+	for i, r := range records {
+		doc.NewPage(i + 1)
+		if err := t.Draw(doc, r); err != nil {
+			return fmt.Errorf("merge: record %d: %v", i+1, err)
+		}
+		doc.EndPage()
+		if err := doc.Err(); err != nil {
+			return err
+		}
+	}
+	return doc.EndDoc()
+}
+
+// MergeEach writes a document for each record, to the writer that create returns for it.
+// The writers are closed after their documents are written.
+func (t Template) MergeEach(records []Record, create func(i int, r Record) (io.WriteCloser, error)) error {
+	for i, r := range records {
+		w, err := create(i, r)
+		if err != nil {
+			return err
+		}
+		if err := t.Merge(w, []Record{r}); err != nil {
+			w.Close()
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Draw draws the template, filled from the record, on the current page
+func (t Template) Draw(doc *pdfgen.PDFDoc, r Record) error {
+	if t.Static != nil {
+		t.Static(doc)
+	}
+	for _, f := range t.Fields {
+		var err error
+		switch f.Kind {
+		case Text:
+			f.text(doc, r)
+		case Image:
+			err = f.image(doc, r)
+		case Table:
+			err = f.table(doc, r)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// minsize is the smallest size to which text is shrunk to fit
+const minsize = 4.0
+
+// text draws a text field from the top of its box, its size reduced until it fits the height
+func (f Field) text(doc *pdfgen.PDFDoc, r Record) {
+	s := f.Text
+	if s == "" {
+		s = r.String(f.Name)
+	} else {
+		s = r.Expand(s)
+	}
+	if s == "" {
+		return
+	}
+	font, size, color := f.Font, f.Size, f.Color
+	if font == "" {
+		font = "sans"
+	}
+	if size <= 0 {
+		size = 12
+	}
+	if color == "" {
+		color = "black"
+	}
+	lines := f.lines(s, font, size)
+	for f.H > 0 && size > minsize && float64(len(lines))*size*1.2 > f.H {
+		size = math.Max(minsize, size*0.9)
+		lines = f.lines(s, font, size)
+	}
+	for i, line := range lines {
+		x := f.X
+		switch f.Align {
+		case table.Center:
+			x += (f.W - pdfgen.TextWidth(line, font, size)) / 2
+		case table.Right:
+			x += f.W - pdfgen.TextWidth(line, font, size)
+		}
+		doc.Text(x, f.Y+f.H-size-float64(i)*size*1.2, line, font, size, color)
+	}
+}
+
+// lines returns the lines of text, broken at newlines and wrapped to the field's width, if it has one
+func (f Field) lines(s, font string, size float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		if f.W <= 0 {
+			lines = append(lines, para)
+			continue
+		}
+		wrapped := pdfgen.WrapText(para, font, size, f.W)
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		lines = append(lines, wrapped...)
+	}
+	return lines
+}
+
+// image draws an image field: the value is the name of an image file, or an image.Image
+func (f Field) image(doc *pdfgen.PDFDoc, r Record) error {
+	v, ok := r.Value(f.Name)
+	if !ok || v == nil {
+		return nil
+	}
+	img, ok := v.(image.Image)
+	if !ok {
+		name := fmt.Sprint(v)
+		if name == "" {
+			return nil
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if img, _, err = image.Decode(file); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	doc.ImageFit(f.X, f.Y, f.W, f.H, pdfgen.Contain, img)
+	return nil
+}
+
+// table draws a table field: the value is rows of cells
+func (f Field) table(doc *pdfgen.PDFDoc, r Record) error {
+	v, ok := r.Value(f.Name)
+	if !ok || v == nil {
+		return nil
+	}
+	rows, err := cells(v)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := f.Columns
+	if len(columns) == 0 {
+		n := 0
+		for _, row := range rows {
+			if len(row) > n {
+				n = len(row)
+			}
+		}
+		columns = make([]table.Column, n)
+		for i := range columns {
+			columns[i].Width = f.W / float64(n)
+		}
+	}
+	t := table.New(columns...)
+	t.Style = f.Style
+	for _, row := range rows {
+		t.AddRow(row...)
+	}
+	if f.Header {
+		t.Header = 1
+	}
+	t.Draw(doc, f.X, f.Y+f.H)
+	return nil
+}
+
+// cells converts a value to rows of cell text: [][]string, or a slice of slices, as read from JSON
+func cells(v interface{}) ([][]string, error) {
+	if rows, ok := v.([][]string); ok {
+		return rows, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("a table must be rows of cells, not %T", v)
+	}
+	rows := make([][]string, len(list))
+	for i, item := range list {
+		switch row := item.(type) {
+		case []string:
+			rows[i] = row
+		case []interface{}:
+			rows[i] = make([]string, len(row))
+			for j, c := range row {
+				rows[i][j] = text(c)
+			}
+		default:
+			return nil, fmt.Errorf("row %d of a table must be cells, not %T", i+1, item)
+		}
+	}
+	return rows, nil
+}
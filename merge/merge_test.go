@@ -0,0 +1,65 @@
+package merge
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const data = `{"name": "Ann", "n": 1234567, "f": 0.5, "big": 12345678901234567890,
+	"customer": {"city": "Oslo", "address": {"zip": "0150"}}, "none": null}`
+
+func record(t *testing.T) Record {
+	records, err := ReadJSON(strings.NewReader(data))
+	if err != nil || len(records) != 1 {
+		t.Fatalf("ReadJSON: %v, %d records", err, len(records))
+	}
+	return records[0]
+}
+
+func TestValue(t *testing.T) {
+	r := record(t)
+	tests := []struct {
+		name string
+		want interface{}
+		ok   bool
+	}{
+		{"name", "Ann", true},
+		{"n", json.Number("1234567"), true},
+		{"customer.city", "Oslo", true},
+		{"customer.address.zip", "0150", true},
+		{"none", nil, true},
+		{"missing", nil, false},
+		{"customer.missing", nil, false},
+		{"name.first", nil, false},
+	}
+	for _, tt := range tests {
+		v, ok := r.Value(tt.name)
+		if v != tt.want || ok != tt.ok {
+			t.Errorf("Value(%q) = %v, %v; want %v, %v", tt.name, v, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	r := record(t)
+	tests := []struct {
+		s, want string
+	}{
+		{"Dear {name},", "Dear Ann,"},
+		{"{ name } of {customer.city}", "Ann of Oslo"},
+		{"{n} {f} {big}", "1234567 0.5 12345678901234567890"},
+		{"[{missing}] [{none}]", "[] []"},
+		{"no placeholders", "no placeholders"},
+		{"{unclosed", "{unclosed"},
+		{"{name}}", "Ann}"},
+	}
+	for _, tt := range tests {
+		if got := r.Expand(tt.s); got != tt.want {
+			t.Errorf("Expand(%q) = %q; want %q", tt.s, got, tt.want)
+		}
+	}
+	if got := (Record{"total": 2500000.0}).String("total"); got != "2500000" {
+		t.Errorf("String of a float = %q; want %q", got, "2500000")
+	}
+}
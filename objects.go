@@ -0,0 +1,91 @@
+package pdfgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Objects other than the catalog, resources, and pages (form XObjects, images, etc.)
+// are numbered following the pages, and written as they are added; those added while
+// a page's content stream is being written are held until the page ends.
+
+// objectwriter returns where an object added now is written: the output, or while
+// a page is drawn, the objects held until it ends
+func (p *PDFDoc) objectwriter() io.Writer {
+	if p.inpage {
+		return &p.objects
+	}
+	return p.out
+}
+
+// addobject adds an object to the document, returning its object number
+func (p *PDFDoc) addobject(def string) int {
+	n := p.nextobj
+	p.nextobj++
+	p.objectcount++
+	fmt.Fprintf(p.objectwriter(), "%d 0 obj\n%s\nendobj\n\n", n, def)
+	return n
+}
+
+// reserveobject returns the number of an object to be defined later with defineobject,
+// for objects that refer to others which refer back to them
+func (p *PDFDoc) reserveobject() int {
+	n := p.nextobj
+	p.nextobj++
+	p.objectcount++
+	return n
+}
+
+// defineobject defines the reserved object n
+func (p *PDFDoc) defineobject(n int, def string) {
+	fmt.Fprintf(p.objectwriter(), "%d 0 obj\n%s\nendobj\n\n", n, def)
+}
+
+// addstream adds a stream object with the dictionary entries in dict, returning its object number
+func (p *PDFDoc) addstream(dict string, data []byte) int {
+	n := p.nextobj
+	p.nextobj++
+	p.objectcount++
+	w := p.objectwriter()
+	fmt.Fprintf(w, "%d 0 obj\n<< %s /Length %d >>\nstream\n", n, dict, len(data))
+	w.Write(data)
+	io.WriteString(w, "\nendstream\nendobj\n\n")
+	return n
+}
+
+// writestream adds a stream object with the dictionary entries in dict and the data written
+// by write, returning its object number. The data is written as it is made, so its length
+// is given by the object following the stream.
+func (p *PDFDoc) writestream(dict string, write func(io.Writer) error) int {
+	n := p.nextobj
+	p.nextobj += 2
+	p.objectcount += 2
+	w := &errwriter{w: p.objectwriter(), err: &p.err}
+	fmt.Fprintf(w, "%d 0 obj\n<< %s /Length %d 0 R >>\nstream\n", n, dict, n+1)
+	start := w.n
+	p.seterr(write(w))
+	length := w.n - start
+	fmt.Fprintf(w, "\nendstream\nendobj\n\n%d 0 obj\n%d\nendobj\n\n", n+1, length)
+	return n
+}
+
+// writeobjects writes the objects held while a page was drawn
+func (p *PDFDoc) writeobjects() {
+	p.out.Write(p.objects.Bytes())
+	p.objects.Reset()
+}
+
+// capture returns the content drawn by draw, without writing it to the page.
+// Changes draw makes to the graphics state are discarded.
+func (p *PDFDoc) capture(draw func(*PDFDoc)) []byte {
+	var buf bytes.Buffer
+	w, gs, stack, inpath := p.Writer, p.graphicsstate, p.gstack, p.inpath
+	p.Writer, p.gstack, p.written = &buf, nil, penops{}
+	draw(p)
+	for len(p.gstack) > 0 {
+		p.Pop()
+	}
+	p.Writer, p.graphicsstate, p.gstack, p.inpath = w, gs, stack, inpath
+	return buf.Bytes()
+}
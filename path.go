@@ -0,0 +1,248 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// FillRule determines which regions of a path are inside, and therefore filled.
+type FillRule int
+
+const (
+	// NonZero is the nonzero winding number rule, the default.
+	NonZero FillRule = iota
+	// EvenOdd is the even-odd rule, where nested subpaths alternate between
+	// inside and outside, producing holes regardless of direction.
+	EvenOdd
+)
+
+// fillop returns the fill operator for the rule
+func (r FillRule) fillop() string {
+	if r == EvenOdd {
+		return "f*"
+	}
+	return "f"
+}
+
+// fillstrokeop returns the fill and stroke operator for the rule
+func (r FillRule) fillstrokeop() string {
+	if r == EvenOdd {
+		return "B*"
+	}
+	return "B"
+}
+
+// SetFillRule sets the rule used by subsequent polygon and path fills
+func (p *PDFDoc) SetFillRule(r FillRule) {
+	p.fillrule = r
+}
+
+// Path construction operators are collected until the path is painted, since
+// the color and line settings used to paint it must precede the path in the page content.
+
+// endpath writes the collected path, followed by the painting operator op
+func (p *PDFDoc) endpath(op string) {
+	p.Writer.Write(p.path.Bytes())
+	fmt.Fprintln(p.Writer, op)
+	p.path.Reset()
+	p.inpath = false
+}
+
+// MoveTo begins a new subpath at (x,y)
+func (p *PDFDoc) MoveTo(x, y float64) {
+	fmt.Fprintf(&p.path, movefmt, x, y)
+	p.inpath = true
+	p.curx, p.cury = x, y
+	p.subx, p.suby = x, y
+}
+
+// LineTo appends a line segment from the current point to (x,y)
+func (p *PDFDoc) LineTo(x, y float64) {
+	fmt.Fprintf(&p.path, lineopfmt, x, y)
+	p.curx, p.cury = x, y
+}
+
+// CurveTo appends a cubic Bezier curve from the current point to (x3,y3),
+// using (x1,y1) and (x2,y2) as control points
+func (p *PDFDoc) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
+	fmt.Fprintf(&p.path, bezierfmt, x1, y1, x2, y2, x3, y3)
+	p.curx, p.cury = x3, y3
+}
+
+// QuadTo appends a quadratic Bezier curve from the current point to (x2,y2),
+// using (x1,y1) as the control point
+func (p *PDFDoc) QuadTo(x1, y1, x2, y2 float64) {
+	x0, y0 := p.curx, p.cury
+	p.CurveTo(x0+(2.0/3.0)*(x1-x0), y0+(2.0/3.0)*(y1-y0), x2+(2.0/3.0)*(x1-x2), y2+(2.0/3.0)*(y1-y2), x2, y2)
+}
+
+// ClosePath closes the current subpath, returning the current point to its start
+func (p *PDFDoc) ClosePath() {
+	fmt.Fprintln(&p.path, "h")
+	p.curx, p.cury = p.subx, p.suby
+}
+
+// StrokePath strokes the current path with the specified width and color
+func (p *PDFDoc) StrokePath(sw float64, color string) {
+	fmt.Fprintf(p.Writer, strokefmt, sw, p.strokeop(color))
+	p.endpath("S")
+}
+
+// FillPath fills the current path with the specified color
+func (p *PDFDoc) FillPath(color string) {
+	fmt.Fprintf(p.Writer, fillfmt, p.fillop(color))
+	p.endpath(p.fillrule.fillop())
+}
+
+// FillStrokePath fills and then strokes the current path, with independent colors
+func (p *PDFDoc) FillStrokePath(sw float64, fillcolor, strokecolor string) {
+	fmt.Fprintf(p.Writer, fsfmt, sw, p.strokeop(strokecolor), p.fillop(fillcolor))
+	p.endpath(p.fillrule.fillstrokeop())
+}
+
+// kappa is the control point distance for approximating a quarter ellipse with a cubic Bezier
+const kappa = 0.5522847498
+
+// ellipsepath builds a closed elliptical path centered at (x,y), with radii w and h
+func (p *PDFDoc) ellipsepath(x, y, w, h float64) {
+	kw, kh := w*kappa, h*kappa
+	p.MoveTo(x+w, y)
+	p.CurveTo(x+w, y+kh, x+kw, y+h, x, y+h)
+	p.CurveTo(x-kw, y+h, x-w, y+kh, x-w, y)
+	p.CurveTo(x-w, y-kh, x-kw, y-h, x, y-h)
+	p.CurveTo(x+kw, y-h, x+w, y-kh, x+w, y)
+	p.ClosePath()
+}
+
+// rectpath builds a rectangular path
+func (p *PDFDoc) rectpath(x, y, w, h float64) {
+	fmt.Fprintf(&p.path, refmt, x, y, w, h)
+	p.inpath = true
+	p.curx, p.cury = x, y
+	p.subx, p.suby = x, y
+}
+
+// polygonpath builds a closed polygonal path, reporting whether the coordinates are usable
+func (p *PDFDoc) polygonpath(x []float64, y []float64) bool {
+	if len(x) != len(y) || len(x) == 0 {
+		return false
+	}
+	p.MoveTo(x[0], y[0])
+	for i := 1; i < len(x); i++ {
+		p.LineTo(x[i], y[i])
+	}
+	p.ClosePath()
+	return true
+}
+
+// ArcTo appends an elliptical arc from (x1,y1) to (x2,y2), using the SVG endpoint
+// parameterization: radii (rx, ry), the rotation of the x axis in degrees,
+// and the large-arc and sweep flags. Sweep selects the arc drawn in the direction
+// of increasing angle. If (x1,y1) is not the current point, a new subpath is begun there.
+// The arc is converted to a series of cubic Bezier curves.
+func (p *PDFDoc) ArcTo(x1, y1, rx, ry, rotation float64, largeArc, sweep bool, x2, y2 float64) {
+	if !p.inpath || p.curx != x1 || p.cury != y1 {
+		p.MoveTo(x1, y1)
+	}
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.LineTo(x2, y2)
+		return
+	}
+	for _, b := range arcbeziers(x1, y1, rx, ry, rotation, largeArc, sweep, x2, y2) {
+		p.CurveTo(b[0], b[1], b[2], b[3], b[4], b[5])
+	}
+}
+
+// arcbeziers converts an endpoint parameterized arc to center parameterization
+// (SVG 1.1, Appendix F.6.5), and returns the cubic Bezier segments
+// (control point 1, control point 2, end point) that approximate it.
+func arcbeziers(x1, y1, rx, ry, rotation float64, largeArc, sweep bool, x2, y2 float64) [][6]float64 {
+	phi := rotation * (math.Pi / 180)
+	sinphi, cosphi := math.Sin(phi), math.Cos(phi)
+
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cosphi*dx + sinphi*dy
+	y1p := -sinphi*dx + cosphi*dy
+
+	// scale up radii that are too small to span the endpoints
+	lambda := (x1p*x1p)/(rx*rx) + (y1p*y1p)/(ry*ry)
+	if lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	rx2, ry2 := rx*rx, ry*ry
+	num := rx2*ry2 - rx2*y1p*y1p - ry2*x1p*x1p
+	den := rx2*y1p*y1p + ry2*x1p*x1p
+	coef := 0.0
+	if num > 0 && den > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+	cx := cosphi*cxp - sinphi*cyp + (x1+x2)/2
+	cy := sinphi*cxp + cosphi*cyp + (y1+y2)/2
+
+	theta := math.Atan2((y1p-cyp)/ry, (x1p-cxp)/rx)
+	delta := math.Atan2((-y1p-cyp)/ry, (-x1p-cxp)/rx) - theta
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	segments := centerbeziers(cx, cy, rx, ry, phi, theta, delta)
+	// land exactly on the requested end point
+	segments[len(segments)-1][4], segments[len(segments)-1][5] = x2, y2
+	return segments
+}
+
+// centerbeziers returns the cubic Bezier segments approximating the arc of the ellipse
+// centered at (cx,cy) with radii (rx,ry) and x axis rotated by phi, starting at angle theta
+// and sweeping delta (angles in radians). Segments span at most 90 degrees.
+func centerbeziers(cx, cy, rx, ry, phi, theta, delta float64) [][6]float64 {
+	sinphi, cosphi := math.Sin(phi), math.Cos(phi)
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	if n < 1 {
+		n = 1
+	}
+	d := delta / float64(n)
+	t := (4.0 / 3.0) * math.Tan(d/4)
+	ellipse := func(ux, uy float64) (float64, float64) {
+		return cx + rx*ux*cosphi - ry*uy*sinphi, cy + rx*ux*sinphi + ry*uy*cosphi
+	}
+	segments := make([][6]float64, n)
+	for i := 0; i < n; i++ {
+		a1 := theta + float64(i)*d
+		a2 := a1 + d
+		cos1, sin1 := math.Cos(a1), math.Sin(a1)
+		cos2, sin2 := math.Cos(a2), math.Sin(a2)
+		c1x, c1y := ellipse(cos1-t*sin1, sin1+t*cos1)
+		c2x, c2y := ellipse(cos2+t*sin2, sin2-t*cos2)
+		ex, ey := ellipse(cos2, sin2)
+		segments[i] = [6]float64{c1x, c1y, c2x, c2y, ex, ey}
+	}
+	return segments
+}
+
+// arcpath appends a circular arc centered at (cx,cy) from angle1 to angle2 (in degrees),
+// beginning with a line from the current point to the start of the arc, if needed
+func (p *PDFDoc) arcpath(cx, cy, r, angle1, angle2 float64) {
+	a1 := angle1 * (math.Pi / 180)
+	a2 := angle2 * (math.Pi / 180)
+	if x, y := cx+r*math.Cos(a1), cy+r*math.Sin(a1); !p.inpath {
+		p.MoveTo(x, y)
+	} else if x != p.curx || y != p.cury {
+		p.LineTo(x, y)
+	}
+	for _, b := range centerbeziers(cx, cy, r, r, 0, a1, a2-a1) {
+		p.CurveTo(b[0], b[1], b[2], b[3], b[4], b[5])
+	}
+}
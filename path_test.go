@@ -0,0 +1,52 @@
+package pdfgen
+
+import (
+	"math"
+	"testing"
+)
+
+// bezierpoint returns the point at t of the cubic Bezier curve from (x0,y0) through segment b
+func bezierpoint(x0, y0 float64, b [6]float64, t float64) (float64, float64) {
+	u := 1 - t
+	x := u*u*u*x0 + 3*u*u*t*b[0] + 3*u*t*t*b[2] + t*t*t*b[4]
+	y := u*u*u*y0 + 3*u*u*t*b[1] + 3*u*t*t*b[3] + t*t*t*b[5]
+	return x, y
+}
+
+func TestArcBeziers(t *testing.T) {
+	tests := []struct {
+		name                string
+		x1, y1, rx, ry, rot float64
+		large, sweep        bool
+		x2, y2              float64
+		cx, cy, r           float64 // the circle the arc lies on
+		segments            int
+	}{
+		{"quarter", 1, 0, 1, 1, 0, false, true, 0, 1, 0, 0, 1, 1},
+		{"three quarters", 1, 0, 1, 1, 0, true, true, 0, 1, 1, 1, 1, 3},
+		{"clockwise quarter", 1, 0, 1, 1, 0, false, false, 0, 1, 1, 1, 1, 1},
+		{"half", 0, 0, 1, 1, 0, false, true, 2, 0, 1, 0, 1, 2},
+		{"radii scaled up", 0, 0, 0.5, 0.5, 0, false, true, 2, 0, 1, 0, 1, 2},
+		{"rotation ignored by a circle", 0, 0, 5, 5, 30, false, true, 10, 0, 5, 0, 5, 2},
+	}
+	for _, tt := range tests {
+		segments := arcbeziers(tt.x1, tt.y1, tt.rx, tt.ry, tt.rot, tt.large, tt.sweep, tt.x2, tt.y2)
+		if len(segments) != tt.segments {
+			t.Errorf("%s: %d segments; want %d", tt.name, len(segments), tt.segments)
+			continue
+		}
+		if end := segments[len(segments)-1]; end[4] != tt.x2 || end[5] != tt.y2 {
+			t.Errorf("%s: ends at (%g,%g); want (%g,%g)", tt.name, end[4], end[5], tt.x2, tt.y2)
+		}
+		x0, y0 := tt.x1, tt.y1
+		for i, b := range segments {
+			for _, at := range []float64{0.25, 0.5, 0.75, 1} {
+				x, y := bezierpoint(x0, y0, b, at)
+				if d := math.Hypot(x-tt.cx, y-tt.cy); math.Abs(d-tt.r) > 1e-3*tt.r {
+					t.Errorf("%s: segment %d at %g is %g from the center; want %g", tt.name, i, at, d, tt.r)
+				}
+			}
+			x0, y0 = b[4], b[5]
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package pdfgen
+
+import (
+	"fmt"
+	"image"
+	"os"
+)
+
+// Pattern is a tiling pattern, defined in a document, for filling shapes.
+type Pattern struct {
+	name string
+}
+
+// HatchStyle is a built-in pattern of lines or dots.
+type HatchStyle int
+
+const (
+	HorizontalHatch HatchStyle = iota
+	VerticalHatch
+	DiagonalHatch
+	CrossHatch
+	DotHatch
+)
+
+// TilePattern defines a pattern that repeats a w by h tile, drawn by draw
+// with its lower left at the origin. The tile is positioned relative to the
+// current coordinate system.
+func (p *PDFDoc) TilePattern(w, h float64, draw func(*PDFDoc)) Pattern {
+	m := p.ctm
+	content := p.capture(draw)
+	obj := p.addstream(fmt.Sprintf("/Type /Pattern /PatternType 1 /PaintType 1 /TilingType 1 /BBox [0 0 %.2f %.2f] /XStep %.2f /YStep %.2f /Matrix [%.5f %.5f %.5f %.5f %.2f %.2f] /Resources 2 0 R",
+		w, h, w, h, m[0], m[1], m[2], m[3], m[4], m[5]), content)
+	return Pattern{name: p.patterns.add("P", fmt.Sprintf("%d 0 R", obj))}
+}
+
+// Hatch defines a pattern of lines (or dots) spacing units apart,
+// drawn with the specified stroke width (or dot radius) and color
+func (p *PDFDoc) Hatch(style HatchStyle, spacing, sw float64, color string) Pattern {
+	s := spacing
+	return p.TilePattern(s, s, func(d *PDFDoc) {
+		switch style {
+		case HorizontalHatch:
+			d.Line(0, s/2, s, s/2, sw, color)
+		case VerticalHatch:
+			d.Line(s/2, 0, s/2, s, sw, color)
+		case DiagonalHatch:
+			d.diagonals(s, sw, color, 1)
+		case CrossHatch:
+			d.diagonals(s, sw, color, 1)
+			d.diagonals(s, sw, color, -1)
+		case DotHatch:
+			d.ellipsepath(s/2, s/2, sw, sw)
+			d.FillPath(color)
+		}
+	})
+}
+
+// diagonals draws lines of the given slope across an s by s tile, including
+// those of the neighboring tiles, extended past the tile edges, so the lines join without gaps
+func (p *PDFDoc) diagonals(s, sw float64, color string, slope float64) {
+	for _, offset := range []float64{-s, 0, s} {
+		if slope > 0 {
+			p.Line(-sw+offset, -sw, s+sw+offset, s+sw, sw, color)
+		} else {
+			p.Line(-sw+offset, s+sw, s+sw+offset, -sw, sw, color)
+		}
+	}
+}
+
+// ImagePattern defines a pattern that repeats the named image file, scaled to a w by h tile
+func (p *PDFDoc) ImagePattern(name string, w, h float64) (Pattern, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return Pattern{}, err
+	}
+	defer r.Close()
+	info, err := p.readimage(r, image.Rectangle{})
+	if err != nil {
+		return Pattern{}, err
+	}
+	return p.TilePattern(w, h, func(d *PDFDoc) {
+		d.placeoriented(0, 0, w, h, info.name, info.orient)
+	}), nil
+}
+
+// FillPathPattern fills the current path with the pattern
+func (p *PDFDoc) FillPathPattern(pat Pattern) {
+	fmt.Fprintf(p.Writer, "/Pattern cs /%s scn\n", pat.name)
+	p.written.fill = ""
+	p.endpath(p.fillrule.fillop())
+}
+
+// PatternRect draws a rectangle, with its lower left at (x,y), filled with the pattern
+func (p *PDFDoc) PatternRect(x, y, w, h float64, pat Pattern) {
+	p.rectpath(x, y, w, h)
+	p.FillPathPattern(pat)
+}
+
+// PatternCircle draws a circle filled with the pattern
+func (p *PDFDoc) PatternCircle(x, y, r float64, pat Pattern) {
+	p.PatternEllipse(x, y, r, r, pat)
+}
+
+// PatternEllipse draws an ellipse filled with the pattern
+func (p *PDFDoc) PatternEllipse(x, y, w, h float64, pat Pattern) {
+	p.ellipsepath(x, y, w, h)
+	p.FillPathPattern(pat)
+}
+
+// PatternPolygon draws a polygon filled with the pattern
+func (p *PDFDoc) PatternPolygon(x []float64, y []float64, pat Pattern) {
+	if p.polygonpath(x, y) {
+		p.FillPathPattern(pat)
+	}
+}
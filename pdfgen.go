@@ -2,9 +2,13 @@
 package pdfgen
 
 import (
+	"bufio"
+	"bytes"
+	"compress/flate"
 	"fmt"
 	"image"
 	"image/color"
+	_ "image/gif"
 	_ "image/png"
 	_ "image/jpeg"
 	"io"
@@ -19,45 +23,94 @@ type PDFDoc struct {
 	width, height float64
 	fontnames     []string
 	objectcount   int
+	page          int
+	pagecount     int
+	measuring     bool
+	inpath        bool
+	path          bytes.Buffer
+	curx, cury    float64
+	subx, suby    float64 // start of the current subpath
+	inpage        bool
+	extgstates    resourcelist
+	shadings      resourcelist
+	patterns      resourcelist
+	xobjects      resourcelist
+	images        map[string]int
+	imagelevel    int
+	imagepredict  bool
+	ignoreexif    bool
+	colorspaces   resourcelist
+	spots         map[string]spotcolor
+	imagecmyk     bool
+	colormode     ColorMode
+	nextobj       int
+	objects       bytes.Buffer
+	npages        int
+	outputintents []string
+	theme         Theme
+	tagged        bool
+	structure     []structelem
+	mcid          int
+	figures       int
+	graphicsstate
+	gstack []graphicsstate
+	err    error
+	buf    *bufio.Writer
+	out    *errwriter
+	lint   *linter
+
+	counting  bool     // record the size of each page, for Measure and Render
+	pagemark  pagesize // size of the document as the current page began
+	pagesizes []pagesize
+
+	glyphs      map[string]map[rune]bool // characters drawn, by font
+	imagereuses int
 }
 
 var fontmap = map[string]string{"sans": "Helvetica", "serif": "Times-Roman", "mono": "Courier", "symbol": "Zapf-Dingbats"}
 
 const (
-	rectfmt    = "%s rg %.2f %.2f %.2f %.2f re f\n"
-	linefmt    = "%.2f w %s RG %.2f %.2f m %.2f %.2f l S\n"
-	curvefmt   = "%.2f w %s RG %.2f %.2f m %.2f %.2f %.2f %.2f v S\n"
+	rectfmt    = "%s %.2f %.2f %.2f %.2f re f\n"
+	linefmt    = "%.2f w %s %.2f %.2f m %.2f %.2f l S\n"
+	curvefmt   = "%.2f w %s %.2f %.2f m %.2f %.2f %.2f %.2f v S\n"
 	arcfmt     = "%.2f %.2f m %.2f %.2f %.2f %.2f v S\n"
-	fillarcfmt = "0 w %s RG %s rg %.2f %.2f m %.2f %.2f l %.2f %.2f %.2f %.2f v b\n"
+	fillarcfmt = "0 w %s %s %.2f %.2f m %.2f %.2f l %.2f %.2f %.2f %.2f v b\n"
 	endfmt     = "trailer\n<</Size %d /Root 1 0 R >>\n%%%%EOF\n"
-	textfmt    = "BT /%s %.2f Tf %.2f %.2f Td %s rg (%s) Tj ET\n"
-	newpagefmt = "%d 0 obj\n<</Type /Page /Parent 1 0 R /Resources 2 0 R /Contents %d 0 R>>\nendobj\n\n%d 0 obj\n<</Length 0>>\nstream\n"
+	textfmt    = "BT /%s %.2f Tf %.2f %.2f Td %s (%s) Tj ET\n"
+	newpagefmt = "%d 0 obj\n<</Type /Page /Parent 1 0 R /Resources 2 0 R /Contents %d 0 R%s>>\nendobj\n\n%d 0 obj\n<</Length 0>>\nstream\n"
 	colorfmt   = "%.3f %.3f %.3f"
-	imagefmt   = "<</Type /XObject\n/Subtype /Image\n/Width %d\n/Height %d\n/ColorSpace /DeviceRGB\n/BitsPerComponent 8\n/Length %d>>\n"
-	inlinefmt  = "q %.2f 0 0 %.2f %.2f %.2f cm\nBI /W %d /H %d /CS /RGB /BPC 8\n"
+	imagefmt   = "/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8"
 	pagefmt    = "] /Count %d /MediaBox [0 0 %v %v]>>\nendobj\n\n"
-	resfmt     = "2 0 obj\n<< /Font\n"
-	fontfmt    = "<< /%s << /Type /Font /Subtype /Type1 /BaseFont /%s >>\n"
+	resfmt     = "2 0 obj\n<< /Font <<\n"
+	fontfmt    = "/%s << /Type /Font /Subtype /Type1 /BaseFont /%s >>\n"
+	movefmt    = "%.2f %.2f m\n"
+	lineopfmt  = "%.2f %.2f l\n"
+	bezierfmt  = "%.2f %.2f %.2f %.2f %.2f %.2f c\n"
+	strokefmt  = "%.2f w %s\n"
+	fillfmt    = "%s\n"
+	fsfmt      = "%.2f w %s %s\n"
+	refmt      = "%.2f %.2f %.2f %.2f re\n"
+	cmfmt      = "%.5f %.5f %.5f %.5f %.2f %.2f cm\n"
 )
 
-func imagestream(w io.Writer, r io.Reader) error {
-	img, _, err := image.Decode(r)
+// imagedata writes the pixels of a decoded image as RGB triples
+func imagedata(w io.Writer, img image.Image) error {
 	switch i := img.(type) {
 		case *image.RGBA:
-			encodeRGBAStream(w, i)
+			return encodeRGBAStream(w, i)
 		case *image.NRGBA:
-			encodeNRGBAStream(w, i)
+			return encodeNRGBAStream(w, i)
 		case *image.YCbCr:
-			encodeYCbCrStream(w, i)
+			return encodeYCbCrStream(w, i)
 		default:
-			encodeImageStream(w, i)
+			return encodeImageStream(w, i)
 		}
-	return err
 }
 
 func encodeImageStream(w io.Writer, img image.Image) error {
 	bd := img.Bounds()
-	row := make([]byte, bd.Dx()*3)
+	row := getrow(bd.Dx() * 3)
+	defer putrow(row)
 	for y := bd.Min.Y; y < bd.Max.Y; y++ {
 		i := 0
 		for x := bd.Min.X; x < bd.Max.X; x++ {
@@ -81,76 +134,130 @@ func encodeImageStream(w io.Writer, img image.Image) error {
 }
 
 func encodeNRGBAStream(w io.Writer, img *image.NRGBA) error {
-	buf := make([]byte, 3*img.Rect.Dx()*img.Rect.Dy())
-	for i, j := 0, 0; i < len(img.Pix); i, j = i+4, j+3 {
-		buf[j+0] = img.Pix[i+0]
-		buf[j+1] = img.Pix[i+1]
-		buf[j+2] = img.Pix[i+2]
+	row := getrow(3 * img.Rect.Dx())
+	defer putrow(row)
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		pix := img.Pix[img.PixOffset(img.Rect.Min.X, y):]
+		for i, j := 0, 0; j < len(row); i, j = i+4, j+3 {
+			row[j+0] = pix[i+0]
+			row[j+1] = pix[i+1]
+			row[j+2] = pix[i+2]
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
 	}
-	_, err := w.Write(buf)
-	return err
+	return nil
 }
 
 func encodeRGBAStream(w io.Writer, img *image.RGBA) error {
-	buf := make([]byte, 3*img.Rect.Dx()*img.Rect.Dy())
+	row := getrow(3 * img.Rect.Dx())
+	defer putrow(row)
 	var a uint16
-	for i, j := 0, 0; i < len(img.Pix); i, j = i+4, j+3 {
-		a = uint16(img.Pix[i+3])
-		if a != 0 {
-			buf[j+0] = byte(uint16(img.Pix[i+0]) * 0xff / a)
-			buf[j+1] = byte(uint16(img.Pix[i+1]) * 0xff / a)
-			buf[j+2] = byte(uint16(img.Pix[i+2]) * 0xff / a)
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		pix := img.Pix[img.PixOffset(img.Rect.Min.X, y):]
+		for i, j := 0, 0; j < len(row); i, j = i+4, j+3 {
+			a = uint16(pix[i+3])
+			if a != 0 {
+				row[j+0] = byte(uint16(pix[i+0]) * 0xff / a)
+				row[j+1] = byte(uint16(pix[i+1]) * 0xff / a)
+				row[j+2] = byte(uint16(pix[i+2]) * 0xff / a)
+			} else {
+				row[j+0], row[j+1], row[j+2] = 0, 0, 0
+			}
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
 		}
 	}
-	_, err := w.Write(buf)
-	return err
+	return nil
 }
 
-
-func encodeYCbCrStream(w io.Writer, img *image.YCbCr) error {
-	var yy, cb, cr uint8
-	var i, j int
-	dx, dy := img.Rect.Dx(), img.Rect.Dy()
-	buf := make([]byte, 3*dx*dy)
-	bi := 0
-	for y := 0; y < dy; y++ {
-		for x := 0; x < dx; x++ {
-			i, j = x, y
-			switch img.SubsampleRatio {
-			case image.YCbCrSubsampleRatio420:
-				j /= 2
-				fallthrough
-			case image.YCbCrSubsampleRatio422:
-				i /= 2
+func encodeGrayStream(w io.Writer, img image.Image) error {
+	if g, ok := img.(*image.Gray); ok {
+		dx := g.Rect.Dx()
+		for y := g.Rect.Min.Y; y < g.Rect.Max.Y; y++ {
+			i := g.PixOffset(g.Rect.Min.X, y)
+			if _, err := w.Write(g.Pix[i : i+dx]); err != nil {
+				return err
 			}
-			yy = img.Y[y*img.YStride+x]
-			cb = img.Cb[j*img.CStride+i]
-			cr = img.Cr[j*img.CStride+i]
+		}
+		return nil
+	}
+	bd := img.Bounds()
+	row := getrow(bd.Dx())
+	defer putrow(row)
+	for y := bd.Min.Y; y < bd.Max.Y; y++ {
+		for x := bd.Min.X; x < bd.Max.X; x++ {
+			row[x-bd.Min.X] = color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeCMYKStream(w io.Writer, img *image.CMYK) error {
+	dx := img.Rect.Dx()
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		i := img.PixOffset(img.Rect.Min.X, y)
+		if _, err := w.Write(img.Pix[i : i+4*dx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			buf[bi+0], buf[bi+1], buf[bi+2] = color.YCbCrToRGB(yy, cb, cr)
+func encodeYCbCrStream(w io.Writer, img *image.YCbCr) error {
+	row := getrow(3 * img.Rect.Dx())
+	defer putrow(row)
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		bi := 0
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			yi, ci := img.YOffset(x, y), img.COffset(x, y)
+			row[bi+0], row[bi+1], row[bi+2] = color.YCbCrToRGB(img.Y[yi], img.Cb[ci], img.Cr[ci])
 			bi += 3
 		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
 	}
-	_, err := w.Write(buf)
-	return err
+	return nil
 }
 
+// bufsize is the size of the buffer of the output, which is written out as each page
+// and the document end, rather than in the many small writes of the drawing methods
+const bufsize = 32 << 10
+
 // NewDoc initializes the document structure.
 func NewDoc(w io.Writer, pagewidth, pageheight float64) *PDFDoc {
-	return &PDFDoc{
-		Writer:      w,
+	p := &PDFDoc{
 		width:       pagewidth,
 		height:      pageheight,
 		fontnames:   []string{fontmap["sans"], fontmap["serif"], fontmap["mono"], fontmap["symbol"]},
 		objectcount: 0,
+		graphicsstate: graphicsstate{
+			miterlimit: defaultmiterlimit,
+			ctm:        identity,
+			pen:        defaultpen,
+			opacity:    opaque,
+			alpha:      opaque,
+		},
+		theme:      LightTheme,
+		imagelevel: flate.DefaultCompression,
 	}
+	p.buf = bufio.NewWriterSize(w, bufsize)
+	p.out = &errwriter{w: p.buf, err: &p.err}
+	p.Writer = p.out
+	return p
 }
 
 // Init begins the document.
 func (p *PDFDoc) Init(n int) {
 	fmt.Fprintln(p.Writer, "%PDF-1.7")
-	p.root(n)
-	p.resources()
+	p.npages = n
+	p.nextobj = (2 * n) + 3
 }
 
 // pdfstring returns an escaped string
@@ -162,12 +269,18 @@ func pdfstring(s string) string {
 	return s
 }
 
-// root defines the document root
-func (p *PDFDoc) root(npages int) {
+// root defines the document root, written at the end of the document
+// since the catalog may refer to objects (output intents, structure) added along the way.
+// entries are further catalog entries.
+func (p *PDFDoc) root(npages int, entries string) {
 	// Object 1 is the root, object 2 is resources.
 	// page references begin at 3, with the contents as the next sequential reference.
 	// For example 3 -> 4, 5 -> 6, etc.
-	fmt.Fprintf(p.Writer, "1 0 obj\n<</Type /Catalog /Pages 3 0 R /Kids [")
+	fmt.Fprintf(p.Writer, "1 0 obj\n<</Type /Catalog /Pages 3 0 R %s", entries)
+	if len(p.outputintents) > 0 {
+		fmt.Fprintf(p.Writer, "/OutputIntents [%s] ", strings.Join(p.outputintents, " "))
+	}
+	fmt.Fprintf(p.Writer, "/Kids [")
 	for i, objref := 0, 3; i < npages; i++ {
 		fmt.Fprintf(p.Writer, "%d 0 R ", objref)
 		objref += 2
@@ -176,26 +289,57 @@ func (p *PDFDoc) root(npages int) {
 	p.objectcount++
 }
 
-// Resources defines page resources: fonts, etc.
+// Resources defines page resources: fonts, graphics states, etc.
+// Since resources are registered as pages are drawn, they are written at the end of the document.
 func (p *PDFDoc) resources() {
-	f := p.fontnames[0]
 	fmt.Fprint(p.Writer, resfmt)
-	//for _, f := range p.fontnames {
-	fmt.Fprintf(p.Writer, fontfmt, f, f)
-	//}
-	fmt.Fprintln(p.Writer, ">>\n>>\nendobj")
+	for _, f := range p.fontnames {
+		fmt.Fprintf(p.Writer, fontfmt, f, f)
+	}
+	fmt.Fprintln(p.Writer, ">>")
+	p.extgstates.write(p.Writer, "ExtGState")
+	p.shadings.write(p.Writer, "Shading")
+	p.patterns.write(p.Writer, "Pattern")
+	p.xobjects.write(p.Writer, "XObject")
+	p.colorspaces.write(p.Writer, "ColorSpace")
+	fmt.Fprint(p.Writer, ">>\nendobj\n\n")
 	p.objectcount++
 }
 
-// EndPage closes out a page
+// EndPage closes out a page, first restoring any graphics state left pushed
 func (p *PDFDoc) EndPage() {
+	if n := len(p.gstack); n > 0 {
+		p.problem(Unbalanced, p.page, "%d Push without a matching Pop", n)
+	}
+	for len(p.gstack) > 0 {
+		p.Pop()
+	}
+	p.lintend()
 	fmt.Fprintf(p.Writer, "endstream\nendobj\n\n")
 	p.objectcount++
+	p.inpage = false
+	p.writeobjects()
+	p.pageend()
+	p.Flush()
 }
 
-// EndDoc closes out the document
-func (p *PDFDoc) EndDoc() {
+// EndDoc closes out the document, and returns its error, as Err does
+func (p *PDFDoc) EndDoc() error {
+	p.lintdoc()
+	structure := p.structtree()
+	p.writeobjects()
+	p.root(p.npages, structure)
+	p.resources()
 	fmt.Fprintf(p.Writer, endfmt, p.objectcount)
+	return p.Flush()
+}
+
+// Flush writes the buffered output to the document's writer, returning the document's error.
+// The output is flushed as each page and the document end; Flush is needed only to send
+// part of a page sooner, or before writing to the document's writer other than through the document.
+func (p *PDFDoc) Flush() error {
+	p.seterr(p.buf.Flush())
+	return p.err
 }
 
 // NewPage sets up a new page
@@ -203,45 +347,131 @@ func (p *PDFDoc) EndDoc() {
 func (p *PDFDoc) NewPage(n int) {
 	obj := (2 * n) + 1
 	ref := obj + 1
-	fmt.Fprintf(p.Writer, newpagefmt, obj, ref, ref)
+	p.pagestart()
+	fmt.Fprintf(p.Writer, newpagefmt, obj, ref, p.pagestructure(n), ref)
+	p.lintbegin(n)
 	p.objectcount++
+	p.page = n
+	p.mcid = 0
+	p.pagecount++
+	p.inpage = true
+	p.ctm = identity
+	p.written = penops{}
+	p.alpha = opaque
+	p.background()
+	p.pagestate()
+}
+
+// Page returns the number of the current page
+func (p *PDFDoc) Page() int {
+	return p.page
+}
+
+// ColorMode selects the colorspace of colors and images.
+type ColorMode int
+
+const (
+	// RGBMode writes colors as given: DeviceGray for "gray(v)" colors, DeviceCMYK
+	// for "cmyk(c,m,y,k)" colors, and DeviceRGB otherwise. Images are RGB.
+	RGBMode ColorMode = iota
+	// GrayMode converts all colors and images to DeviceGray.
+	GrayMode
+)
+
+// SetColorMode sets the colorspace of subsequent colors and images
+func (p *PDFDoc) SetColorMode(m ColorMode) {
+	p.colormode = m
+}
+
+// coloroperator returns the operator setting the fill (or stroke) color to the color string,
+// in the colorspace of the string and color mode
+func (p *PDFDoc) coloroperator(color string, stroke bool) string {
+	color, _ = colorbase(p.rolecolor(color))
+	if spot, tint, ok := p.spotcolor(color); ok {
+		if p.colormode == GrayMode {
+			color = fmt.Sprintf("cmyk(%g,%g,%g,%g)", spot.c*tint, spot.m*tint, spot.y*tint, spot.k*tint)
+		} else if stroke {
+			return fmt.Sprintf("/%s CS %.3f SCN", spot.name, tint)
+		} else {
+			return fmt.Sprintf("/%s cs %.3f scn", spot.name, tint)
+		}
+	}
+	if v, ok := graycolor(color); ok || p.colormode == GrayMode {
+		if !ok {
+			v = luminance(colorlookup(color))
+		}
+		if stroke {
+			return fmt.Sprintf("%.3f G", v)
+		}
+		return fmt.Sprintf("%.3f g", v)
+	}
+	if c, m, y, k, ok := cmykcolor(color); ok {
+		op := "k"
+		if stroke {
+			op = "K"
+		}
+		return fmt.Sprintf("%.3f %.3f %.3f %.3f %s", c, m, y, k, op)
+	}
+	if stroke {
+		return pdfcolor(color) + " RG"
+	}
+	return pdfcolor(color) + " rg"
 }
 
 // pdfcolor converts a color string to the PDF (RGB) format
 func pdfcolor(color string) string {
-	r, g, b := colorlookup(color)
+	r, g, b, _ := colorlookup(color)
 	return fmt.Sprintf(colorfmt, float64(r)/255.0, float64(g)/255.0, float64(b)/255.0)
 }
 
-// placeimage places an image
-func (p *PDFDoc) placeimage(x, y, w, h float64, id string) {
-	fmt.Fprintf(p.Writer, "q %.2f 0 0 %.2f %.2f %.2f cm /I%s Do Q\n", w, h, x, y, id)
+// placeimage places the image XObject with the resource name, scaled to w by h, with its lower left at (x,y)
+func (p *PDFDoc) placeimage(x, y, w, h float64, name string) {
+	fmt.Fprintf(p.Writer, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q\n", w, h, x, y, name)
 }
 
 // Text draws attributed (font, size, color) text at a (x,y) location
 func (p *PDFDoc) Text(x, y float64, s, font string, size float64, color string) {
-	fmt.Fprintf(p.Writer, textfmt, fontmap[font], size, x, y, pdfcolor(color), pdfstring(s))
+	if p.shadow != nil {
+		p.castshadow(func(dx, dy, spread, angle float64) {
+			p.text(x+dx+spread*math.Cos(angle), y+dy+spread*math.Sin(angle), s, font, size, p.shadow.color)
+		})
+	}
+	p.text(x, y, s, font, size, color)
 }
 
-// Image places an image at the (x,y) location
+// text draws attributed text, without a shadow
+func (p *PDFDoc) text(x, y float64, s, font string, size float64, color string) {
+	name := p.fontname(font)
+	p.usefont(name, s)
+	fmt.Fprintf(p.Writer, textfmt, name, size, x, y, p.fillop(color), pdfstring(s))
+}
+
+// Image places an image at the (x,y) location. An image that cannot be read is the document's error.
 func (p *PDFDoc) Image(x, y float64, width, height int, scale float64, name string) {
 	r, err := os.Open(name)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
+		p.seterr(err)
 		return
 	}
-	fw := float64(width) * (scale / 100)
-	fh := float64(height) * (scale / 100)
-	fmt.Fprintf(p.Writer, inlinefmt, fw, fh, x, y, width, height)
-	fmt.Fprintf(p.Writer, "ID ")
-	err = imagestream(p.Writer, r)
+	defer r.Close()
+	p.seterr(p.ImageReader(x, y, float64(width), float64(height), scale, r))
+}
+
+// ImageReader places an image read from r at the (x,y) location, w by h scaled by scale percent.
+// Any of the registered image formats may be read.
+func (p *PDFDoc) ImageReader(x, y, w, h, scale float64, r io.Reader) error {
+	info, err := p.readimage(r, image.Rectangle{})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		return
+		return err
 	}
-	//io.Copy(p.Writer, r)
-	fmt.Fprintf(p.Writer, " EI\nQ\n")
-	r.Close()
+	p.placeoriented(x, y, w*(scale/100), h*(scale/100), info.name, info.orient)
+	return nil
+}
+
+// SetImageCMYK sets whether CMYK images (such as CMYK JPEGs) are placed in the DeviceCMYK
+// colorspace as they are, rather than being converted to RGB
+func (p *PDFDoc) SetImageCMYK(keep bool) {
+	p.imagecmyk = keep
 }
 
 // Polygon draws a colored polygon
@@ -249,21 +479,39 @@ func (p *PDFDoc) Polygon(x []float64, y []float64, color string) {
 	if len(x) != len(y) {
 		return
 	}
-	fmt.Fprintf(p.Writer, "%s rg %v %v m", pdfcolor(color), x[0], y[0])
+	fmt.Fprintf(p.Writer, "%s %v %v m", p.fillop(color), x[0], y[0])
 	for i := 1; i < len(x); i++ {
 		fmt.Fprintf(p.Writer, " %v %v l", x[i], y[i])
 	}
-	fmt.Fprintf(p.Writer, " %v %v l f\n", x[0], y[0])
+	fmt.Fprintf(p.Writer, " %v %v l %s\n", x[0], y[0], p.fillrule.fillop())
+}
+
+// Polyline strokes a connected series of line segments with specified stroke color and width
+func (p *PDFDoc) Polyline(x []float64, y []float64, sw float64, color string) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	fmt.Fprintf(p.Writer, "%.2f w %s %.2f %.2f m", sw, p.strokeop(color), x[0], y[0])
+	for i := 1; i < len(x); i++ {
+		fmt.Fprintf(p.Writer, " %.2f %.2f l", x[i], y[i])
+	}
+	fmt.Fprintln(p.Writer, " S")
 }
 
 // Line draws a line with specified stroke color and width
 func (p *PDFDoc) Line(x1, y1, x2, y2, sw float64, color string) {
-	fmt.Fprintf(p.Writer, linefmt, sw, pdfcolor(color), x1, y1, x2, y2)
+	fmt.Fprintf(p.Writer, linefmt, sw, p.strokeop(color), x1, y1, x2, y2)
 }
 
 // Rect draws a colored rectangle with the upper left at (x,y)
 func (p *PDFDoc) Rect(x, y, w, h float64, color string) {
-	fmt.Fprintf(p.Writer, rectfmt, pdfcolor(color), x, y, w, h)
+	if p.shadow != nil {
+		p.castshadow(func(dx, dy, spread, angle float64) {
+			p.roundrectpath(x+dx-spread, y+dy-spread, w+2*spread, h+2*spread, spread)
+			p.FillPath(p.shadow.color)
+		})
+	}
+	fmt.Fprintf(p.Writer, rectfmt, p.fillop(color), x, y, w, h)
 }
 
 // Square draws a colored square with the upper left at (x,y)
@@ -273,7 +521,7 @@ func (p *PDFDoc) Square(x, y, w float64, color string) {
 
 // Curve draws a quadratic Bezier curve at the specified stroke color and width
 func (p *PDFDoc) Curve(x1, y1, x2, y2, x3, y3, sw float64, color string) {
-	fmt.Fprintf(p.Writer, curvefmt, sw, pdfcolor(color), x1, y1, x2, y2, x3, y3)
+	fmt.Fprintf(p.Writer, curvefmt, sw, p.strokeop(color), x1, y1, x2, y2, x3, y3)
 }
 
 // Circle draws a color filled circle
@@ -286,6 +534,54 @@ func (p *PDFDoc) Ellipse(x, y, w, h float64, color string) {
 	p.FillArc(x, y, w, h, 0, 360, color)
 }
 
+// StrokePolygon draws the outline of a polygon with specified stroke color and width
+func (p *PDFDoc) StrokePolygon(x []float64, y []float64, sw float64, color string) {
+	if p.polygonpath(x, y) {
+		p.StrokePath(sw, color)
+	}
+}
+
+// FillStrokePolygon draws a polygon filled and outlined with independent colors
+func (p *PDFDoc) FillStrokePolygon(x []float64, y []float64, sw float64, fillcolor, strokecolor string) {
+	if p.polygonpath(x, y) {
+		p.FillStrokePath(sw, fillcolor, strokecolor)
+	}
+}
+
+// StrokeRect draws the outline of a rectangle with specified stroke color and width
+func (p *PDFDoc) StrokeRect(x, y, w, h, sw float64, color string) {
+	p.rectpath(x, y, w, h)
+	p.StrokePath(sw, color)
+}
+
+// FillStrokeRect draws a rectangle filled and outlined with independent colors
+func (p *PDFDoc) FillStrokeRect(x, y, w, h, sw float64, fillcolor, strokecolor string) {
+	p.rectpath(x, y, w, h)
+	p.FillStrokePath(sw, fillcolor, strokecolor)
+}
+
+// StrokeCircle draws the outline of a circle with specified stroke color and width
+func (p *PDFDoc) StrokeCircle(x, y, r, sw float64, color string) {
+	p.StrokeEllipse(x, y, r, r, sw, color)
+}
+
+// FillStrokeCircle draws a circle filled and outlined with independent colors
+func (p *PDFDoc) FillStrokeCircle(x, y, r, sw float64, fillcolor, strokecolor string) {
+	p.FillStrokeEllipse(x, y, r, r, sw, fillcolor, strokecolor)
+}
+
+// StrokeEllipse draws the outline of an ellipse with specified stroke color and width
+func (p *PDFDoc) StrokeEllipse(x, y, w, h, sw float64, color string) {
+	p.ellipsepath(x, y, w, h)
+	p.StrokePath(sw, color)
+}
+
+// FillStrokeEllipse draws an ellipse filled and outlined with independent colors
+func (p *PDFDoc) FillStrokeEllipse(x, y, w, h, sw float64, fillcolor, strokecolor string) {
+	p.ellipsepath(x, y, w, h)
+	p.FillStrokePath(sw, fillcolor, strokecolor)
+}
+
 func arcdata(i int, x, y, w, h, angle1, angle2 float64) (float64, float64, float64, float64, float64, float64) {
 	const n = 16
 
@@ -311,14 +607,14 @@ func (p *PDFDoc) FillArc(x, y, w, h, angle1, angle2 float64, color string) {
 	const n = 16
 	for i := 0; i < n; i++ {
 		x0, y0, cx, cy, x2, y2 := arcdata(i, x, y, w, h, angle1, angle2)
-		fmt.Fprintf(p.Writer, fillarcfmt, pdfcolor(color), pdfcolor(color), x, y, x0, y0, cx, cy, x2, y2)
+		fmt.Fprintf(p.Writer, fillarcfmt, p.strokeop(color), p.fillop(color), x, y, x0, y0, cx, cy, x2, y2)
 	}
 }
 
 // Arc strokes an elliptical arc, using a series of quadratic Bezier curves
 func (p *PDFDoc) Arc(x, y, w, h, angle1, angle2, sw float64, color string) {
 	const n = 16
-	fmt.Fprintf(p.Writer, "%s RG %.2f w\n", pdfcolor(color), sw)
+	fmt.Fprintf(p.Writer, "%s %.2f w\n", p.strokeop(color), sw)
 	for i := 0; i < n; i++ {
 		x0, y0, cx, cy, x2, y2 := arcdata(i, x, y, w, h, angle1, angle2)
 		fmt.Fprintf(p.Writer, arcfmt, x0, y0, cx, cy, x2, y2)
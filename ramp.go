@@ -0,0 +1,94 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// InterpolateColor returns the color at t (from 0 to 1) between the colors c1 and c2,
+// interpolating each RGB component and the opacity
+func InterpolateColor(c1, c2 string, t float64) string {
+	t = unit(t)
+	r1, g1, b1, a1 := colorlookup(c1)
+	r2, g2, b2, a2 := colorlookup(c2)
+	mix := func(v1, v2 int) int {
+		return int(math.Round(float64(v1) + t*float64(v2-v1)))
+	}
+	a := a1 + t*(a2-a1)
+	if a >= 1 {
+		return fmt.Sprintf("#%02x%02x%02x", mix(r1, r2), mix(g1, g2), mix(b1, b2))
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", mix(r1, r2), mix(g1, g2), mix(b1, b2), int(math.Round(a*255)))
+}
+
+// Ramp is a color scale: a sequence of colors, evenly spaced from 0 to 1,
+// between which colors are interpolated.
+type Ramp []string
+
+// Sequential ramps, from low to high values
+var (
+	Viridis = Ramp{"#440154", "#482878", "#3e4989", "#31688e", "#26828e", "#1f9e89", "#35b779", "#6ece58", "#b5de2b", "#fde725"}
+	Blues   = Ramp{"#f7fbff", "#deebf7", "#c6dbef", "#9ecae1", "#6baed6", "#4292c6", "#2171b5", "#08519c", "#08306b"}
+	Greens  = Ramp{"#f7fcf5", "#e5f5e0", "#c7e9c0", "#a1d99b", "#74c476", "#41ab5d", "#238b45", "#006d2c", "#00441b"}
+	Grays   = Ramp{"#ffffff", "#000000"}
+)
+
+// Diverging ramps, from low through a neutral middle to high values
+var (
+	RedBlue     = Ramp{"#67001f", "#b2182b", "#d6604d", "#f4a582", "#fddbc7", "#f7f7f7", "#d1e5f0", "#92c5de", "#4393c3", "#2166ac", "#053061"}
+	BrownTeal   = Ramp{"#543005", "#8c510a", "#bf812d", "#dfc27d", "#f6e8c3", "#f5f5f5", "#c7eae5", "#80cdc1", "#35978f", "#01665e", "#003c30"}
+	PurpleGreen = Ramp{"#40004b", "#762a83", "#9970ab", "#c2a5cf", "#e7d4e8", "#f7f7f7", "#d9f0d3", "#a6dba0", "#5aae61", "#1b7837", "#00441b"}
+)
+
+// At returns the color of the ramp at t, from 0 to 1
+func (r Ramp) At(t float64) string {
+	switch len(r) {
+	case 0:
+		return "black"
+	case 1:
+		return r[0]
+	}
+	pos := unit(t) * float64(len(r)-1)
+	i := int(pos)
+	if i >= len(r)-1 {
+		i = len(r) - 2
+	}
+	return InterpolateColor(r[i], r[i+1], pos-float64(i))
+}
+
+// Value returns the color of the ramp for v in the range min to max.
+// Values outside the range take the color at its ends.
+func (r Ramp) Value(v, min, max float64) string {
+	if max == min {
+		return r.At(0.5)
+	}
+	return r.At((v - min) / (max - min))
+}
+
+// Diverging returns the color of a diverging ramp for v in the range min to max,
+// with mid (for example zero) at the middle of the ramp
+func (r Ramp) Diverging(v, min, mid, max float64) string {
+	switch {
+	case v < mid && mid > min:
+		return r.At(0.5 * (v - min) / (mid - min))
+	case v > mid && max > mid:
+		return r.At(0.5 + 0.5*(v-mid)/(max-mid))
+	}
+	return r.At(0.5)
+}
+
+// Colors returns n colors evenly sampled from the ramp, including both ends; none if n is not positive
+func (r Ramp) Colors(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	colors := make([]string, n)
+	for i := range colors {
+		if n == 1 {
+			colors[i] = r.At(0.5)
+			continue
+		}
+		colors[i] = r.At(float64(i) / float64(n-1))
+	}
+	return colors
+}
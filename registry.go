@@ -0,0 +1,110 @@
+package pdfgen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownColor is returned for a color string that is not a known name or color specification.
+// Drawing with an unknown color draws in black.
+var ErrUnknownColor = errors.New("pdfgen: unknown color")
+
+// registered maps user-defined color names to color strings
+var registered = struct {
+	sync.RWMutex
+	colors map[string]string
+}{colors: make(map[string]string)}
+
+// RegisterColor defines a named color (for example "acme-blue") from a RGB triple, usable
+// wherever a color string is taken, in every document. A registered name takes precedence
+// over the SVG color of the same name.
+func RegisterColor(name string, r, g, b int) error {
+	return registercolor(name, fmt.Sprintf("#%02x%02x%02x", clampbyte(r), clampbyte(g), clampbyte(b)))
+}
+
+// RegisterPalette defines the named colors of the palette, each given as a color string,
+// for example {"acme-blue": "#0055a4", "acme-ink": "cmyk(0.9,0.7,0,0.2)"}.
+// No colors are registered if any of the colors is unknown.
+func RegisterPalette(palette map[string]string) error {
+	for name, color := range palette {
+		if err := CheckColor(color); err != nil {
+			return fmt.Errorf("%w: %q for %q", ErrUnknownColor, color, name)
+		}
+		if !colorname(name) {
+			return fmt.Errorf("pdfgen: invalid color name %q", name)
+		}
+	}
+	for name, color := range palette {
+		registercolor(name, color)
+	}
+	return nil
+}
+
+// registercolor maps the name to the color string; references to other
+// registered names are resolved now, so that names never refer to each other
+func registercolor(name, color string) error {
+	if !colorname(name) {
+		return fmt.Errorf("pdfgen: invalid color name %q", name)
+	}
+	color = resolvecolor(color)
+	registered.Lock()
+	registered.colors[name] = color
+	registered.Unlock()
+	return nil
+}
+
+// colorname reports whether s may name a color: it is not empty,
+// and is not confused with a hex or functional color
+func colorname(s string) bool {
+	return s != "" && s != "none" && !strings.HasPrefix(s, "#") && !strings.ContainsAny(s, "() ,/")
+}
+
+// resolvecolor returns the color string a registered name stands for, or s
+func resolvecolor(s string) string {
+	registered.RLock()
+	defer registered.RUnlock()
+	if c, ok := registered.colors[s]; ok {
+		return c
+	}
+	return s
+}
+
+// CheckColor returns ErrUnknownColor if s is not a registered or SVG color name,
+// "none", a hex color, or a rgb, rgba, hsl, hsla, gray, cmyk, or spot functional color,
+// optionally followed by an opacity percentage ("red/50")
+func CheckColor(s string) error {
+	s, _ = colorbase(s)
+	if _, ok := colornames[s]; ok || s == "none" {
+		return nil
+	}
+	if strings.HasPrefix(s, "#") {
+		switch len(s) {
+		case 4, 7, 9:
+			if _, err := strconv.ParseUint(s[1:], 16, 32); err == nil {
+				return nil
+			}
+		}
+		return ErrUnknownColor
+	}
+	if open := strings.Index(s, "("); open > 0 && strings.HasSuffix(s, ")") {
+		switch strings.ToLower(strings.TrimSpace(s[:open])) {
+		case "rgb", "rgba", "hsl", "hsla", "gray", "cmyk", "spot":
+			return nil
+		}
+	}
+	return ErrUnknownColor
+}
+
+// clampbyte limits v to the range 0-255
+func clampbyte(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return v
+}
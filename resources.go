@@ -0,0 +1,56 @@
+package pdfgen
+
+import (
+	"fmt"
+	"io"
+)
+
+// resourcelist is a set of named entries of one category in the resource dictionary,
+// for example ExtGState. Identical definitions share one name.
+type resourcelist struct {
+	names []string
+	defs  []string
+	index map[string]string
+}
+
+// add registers the definition (a PDF object, or a reference to one) and returns its
+// resource name, made from prefix and a sequence number
+func (r *resourcelist) add(prefix, def string) string {
+	if name, ok := r.index[def]; ok {
+		return name
+	}
+	if r.index == nil {
+		r.index = make(map[string]string)
+	}
+	name := fmt.Sprintf("%s%d", prefix, len(r.names)+1)
+	r.names = append(r.names, name)
+	r.defs = append(r.defs, def)
+	r.index[def] = name
+	return name
+}
+
+// set registers the definition under a fixed resource name, such as DefaultRGB,
+// replacing any earlier definition of that name
+func (r *resourcelist) set(name, def string) {
+	for i, n := range r.names {
+		if n == name {
+			delete(r.index, r.defs[i])
+			r.defs[i] = def
+			return
+		}
+	}
+	r.names = append(r.names, name)
+	r.defs = append(r.defs, def)
+}
+
+// write writes the entries as the named category of a resource dictionary
+func (r *resourcelist) write(w io.Writer, category string) {
+	if len(r.names) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "/%s <<\n", category)
+	for i, name := range r.names {
+		fmt.Fprintf(w, "/%s %s\n", name, r.defs[i])
+	}
+	fmt.Fprintln(w, ">>")
+}
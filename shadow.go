@@ -0,0 +1,44 @@
+package pdfgen
+
+import "math"
+
+// shadow describes the shadow cast by rectangles and text.
+type shadow struct {
+	dx, dy, blur float64
+	color        string
+}
+
+// shadowlayers is the number of translucent layers that make up a blurred shadow
+const shadowlayers = 8
+
+// shadowopacity is the opacity of the shadow where all layers overlap
+const shadowopacity = 0.6
+
+// SetShadow makes subsequent rectangles, rounded rectangles, and text cast a shadow
+// of the specified color, offset by (dx,dy), with its edges softened over blur units
+func (p *PDFDoc) SetShadow(dx, dy, blur float64, color string) {
+	p.shadow = &shadow{dx: dx, dy: dy, blur: math.Max(0, blur), color: color}
+}
+
+// ClearShadow stops shapes and text from casting shadows
+func (p *PDFDoc) ClearShadow() {
+	p.shadow = nil
+}
+
+// castshadow draws the shadow in layers, from the most spread out to the least, so that
+// the overlapping translucent layers are darker at the center and fade toward the edges.
+// draw makes one layer, at offset (dx,dy), spread by spread; text uses angle to displace its layers.
+func (p *PDFDoc) castshadow(draw func(dx, dy, spread, angle float64)) {
+	s := p.shadow
+	n := shadowlayers
+	if s.blur == 0 {
+		n = 1
+	}
+	p.Push()
+	p.opacity.fill *= 1 - math.Pow(1-shadowopacity, 1/float64(n))
+	for i := n; i > 0; i-- {
+		spread := s.blur * float64(i-1) / float64(n)
+		draw(s.dx, s.dy, spread, float64(i)*(2*math.Pi/float64(n)))
+	}
+	p.Pop()
+}
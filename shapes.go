@@ -0,0 +1,193 @@
+package pdfgen
+
+import "math"
+
+// Capsule draws a colored pill shape: a rectangle with fully rounded ends,
+// with its lower left at (x,y)
+func (p *PDFDoc) Capsule(x, y, w, h float64, color string) {
+	p.roundrectpath(x, y, w, h, math.Min(w, h)/2)
+	p.FillPath(color)
+}
+
+// StrokeCapsule draws the outline of a pill shape with specified stroke color and width
+func (p *PDFDoc) StrokeCapsule(x, y, w, h, sw float64, color string) {
+	p.roundrectpath(x, y, w, h, math.Min(w, h)/2)
+	p.StrokePath(sw, color)
+}
+
+// ChamferRect draws a colored rectangle with its corners cut off at
+// distance c, with its lower left at (x,y)
+func (p *PDFDoc) ChamferRect(x, y, w, h, c float64, color string) {
+	p.chamferpath(x, y, w, h, c)
+	p.FillPath(color)
+}
+
+// StrokeChamferRect draws the outline of a chamfered rectangle with specified stroke color and width
+func (p *PDFDoc) StrokeChamferRect(x, y, w, h, c, sw float64, color string) {
+	p.chamferpath(x, y, w, h, c)
+	p.StrokePath(sw, color)
+}
+
+// RoundRect draws a colored rectangle with corners of radius r, with its lower left at (x,y)
+func (p *PDFDoc) RoundRect(x, y, w, h, r float64, color string) {
+	if p.shadow != nil {
+		p.castshadow(func(dx, dy, spread, angle float64) {
+			p.roundrectpath(x+dx-spread, y+dy-spread, w+2*spread, h+2*spread, r+spread)
+			p.FillPath(p.shadow.color)
+		})
+	}
+	p.roundrectpath(x, y, w, h, r)
+	p.FillPath(color)
+}
+
+// StrokeRoundRect draws the outline of a rounded rectangle with specified stroke color and width
+func (p *PDFDoc) StrokeRoundRect(x, y, w, h, r, sw float64, color string) {
+	p.roundrectpath(x, y, w, h, r)
+	p.StrokePath(sw, color)
+}
+
+// roundrectpath builds a rectangular path with corners of radius r
+func (p *PDFDoc) roundrectpath(x, y, w, h, r float64) {
+	r = math.Max(0, math.Min(r, math.Min(w, h)/2))
+	k := r * kappa
+	p.MoveTo(x+r, y)
+	p.LineTo(x+w-r, y)
+	p.CurveTo(x+w-r+k, y, x+w, y+r-k, x+w, y+r)
+	p.LineTo(x+w, y+h-r)
+	p.CurveTo(x+w, y+h-r+k, x+w-r+k, y+h, x+w-r, y+h)
+	p.LineTo(x+r, y+h)
+	p.CurveTo(x+r-k, y+h, x, y+h-r+k, x, y+h-r)
+	p.LineTo(x, y+r)
+	p.CurveTo(x, y+r-k, x+r-k, y, x+r, y)
+	p.ClosePath()
+}
+
+// chamferpath builds a rectangular path with corners cut at distance c
+func (p *PDFDoc) chamferpath(x, y, w, h, c float64) {
+	c = math.Max(0, math.Min(c, math.Min(w, h)/2))
+	p.MoveTo(x+c, y)
+	p.LineTo(x+w-c, y)
+	p.LineTo(x+w, y+c)
+	p.LineTo(x+w, y+h-c)
+	p.LineTo(x+w-c, y+h)
+	p.LineTo(x+c, y+h)
+	p.LineTo(x, y+h-c)
+	p.LineTo(x, y+c)
+	p.ClosePath()
+}
+
+// RegularPolygon draws a colored regular polygon centered at (cx,cy), with its
+// vertices at radius r. With a rotation of zero, the first vertex points up;
+// rotation is in degrees, counterclockwise.
+func (p *PDFDoc) RegularPolygon(cx, cy, r float64, sides int, rotation float64, color string) {
+	if sides < 3 {
+		return
+	}
+	x, y := polarpoints(cx, cy, []float64{r}, sides, rotation)
+	p.Polygon(x, y, color)
+}
+
+// StrokeRegularPolygon draws the outline of a regular polygon with specified stroke color and width
+func (p *PDFDoc) StrokeRegularPolygon(cx, cy, r float64, sides int, rotation, sw float64, color string) {
+	if sides < 3 {
+		return
+	}
+	x, y := polarpoints(cx, cy, []float64{r}, sides, rotation)
+	p.StrokePolygon(x, y, sw, color)
+}
+
+// polarpoints returns n points evenly spaced around (cx,cy), starting at the top
+// and offset by rotation degrees. The radii are used in turn for successive points.
+func polarpoints(cx, cy float64, radii []float64, n int, rotation float64) ([]float64, []float64) {
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		a := (90 + rotation + float64(i)*360/float64(n)) * (math.Pi / 180)
+		r := radii[i%len(radii)]
+		x[i] = cx + r*math.Cos(a)
+		y[i] = cy + r*math.Sin(a)
+	}
+	return x, y
+}
+
+// Star draws a colored star centered at (cx,cy), with the specified number of points
+// alternating between the outer and inner radii. With a rotation of zero,
+// the first point faces up; rotation is in degrees, counterclockwise.
+func (p *PDFDoc) Star(cx, cy, outerR, innerR float64, points int, rotation float64, color string) {
+	if points < 2 {
+		return
+	}
+	x, y := polarpoints(cx, cy, []float64{outerR, innerR}, points*2, rotation)
+	p.Polygon(x, y, color)
+}
+
+// StrokeStar draws the outline of a star with specified stroke color and width
+func (p *PDFDoc) StrokeStar(cx, cy, outerR, innerR float64, points int, rotation, sw float64, color string) {
+	if points < 2 {
+		return
+	}
+	x, y := polarpoints(cx, cy, []float64{outerR, innerR}, points*2, rotation)
+	p.StrokePolygon(x, y, sw, color)
+}
+
+// Wedge draws a colored pie wedge: the sector of the circle centered at (cx,cy) with radius r,
+// bounded by the radii at startAngle and endAngle (in degrees, counterclockwise from the x axis)
+func (p *PDFDoc) Wedge(cx, cy, r, startAngle, endAngle float64, color string) {
+	p.wedgepath(cx, cy, r, startAngle, endAngle)
+	p.FillPath(color)
+}
+
+// StrokeWedge draws the outline of a pie wedge with specified stroke color and width
+func (p *PDFDoc) StrokeWedge(cx, cy, r, startAngle, endAngle, sw float64, color string) {
+	p.wedgepath(cx, cy, r, startAngle, endAngle)
+	p.StrokePath(sw, color)
+}
+
+// wedgepath builds a closed path around a circular sector
+func (p *PDFDoc) wedgepath(cx, cy, r, startAngle, endAngle float64) {
+	p.MoveTo(cx, cy)
+	p.arcpath(cx, cy, r, startAngle, endAngle)
+	p.ClosePath()
+}
+
+// RingSegment draws a colored annular sector: the part of the ring between radii innerR and outerR
+// centered at (cx,cy), from startAngle to endAngle (in degrees, counterclockwise from the x axis).
+// The segment is filled as a single path, so a full ring has no seams.
+func (p *PDFDoc) RingSegment(cx, cy, innerR, outerR, startAngle, endAngle float64, color string) {
+	p.ringpath(cx, cy, innerR, outerR, startAngle, endAngle)
+	p.FillPath(color)
+}
+
+// StrokeRingSegment draws the outline of an annular sector with specified stroke color and width
+func (p *PDFDoc) StrokeRingSegment(cx, cy, innerR, outerR, startAngle, endAngle, sw float64, color string) {
+	p.ringpath(cx, cy, innerR, outerR, startAngle, endAngle)
+	p.StrokePath(sw, color)
+}
+
+// ringpath builds a closed path around an annular sector
+func (p *PDFDoc) ringpath(cx, cy, innerR, outerR, startAngle, endAngle float64) {
+	p.inpath = false
+	p.arcpath(cx, cy, outerR, startAngle, endAngle)
+	p.arcpath(cx, cy, innerR, endAngle, startAngle)
+	p.ClosePath()
+}
+
+// Grid draws a grid of lines with its lower left at (x,y), spaced xstep apart horizontally
+// and ystep apart vertically, with specified stroke color and width
+func (p *PDFDoc) Grid(x, y, w, h, xstep, ystep, sw float64, color string) {
+	if xstep > 0 {
+		for i := 0; i <= int(w/xstep+1e-9); i++ {
+			ix := x + float64(i)*xstep
+			p.MoveTo(ix, y)
+			p.LineTo(ix, y+h)
+		}
+	}
+	if ystep > 0 {
+		for i := 0; i <= int(h/ystep+1e-9); i++ {
+			iy := y + float64(i)*ystep
+			p.MoveTo(x, iy)
+			p.LineTo(x+w, iy)
+		}
+	}
+	p.StrokePath(sw, color)
+}
@@ -0,0 +1,77 @@
+package pdfgen
+
+// Spline strokes a smooth curve through the (x,y) points, with specified stroke color and width.
+// Each span is a Catmull-Rom segment, converted to a cubic Bezier curve.
+func (p *PDFDoc) Spline(x []float64, y []float64, sw float64, color string) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	p.splinepath(x, y)
+	p.StrokePath(sw, color)
+}
+
+// splinepath builds an open Catmull-Rom path through the points,
+// repeating the end points to supply the missing neighbors
+func (p *PDFDoc) splinepath(x []float64, y []float64) {
+	n := len(x)
+	at := func(i int) (float64, float64) {
+		if i < 0 {
+			i = 0
+		}
+		if i >= n {
+			i = n - 1
+		}
+		return x[i], y[i]
+	}
+	p.MoveTo(x[0], y[0])
+	for i := 0; i < n-1; i++ {
+		x0, y0 := at(i - 1)
+		x1, y1 := at(i)
+		x2, y2 := at(i + 1)
+		x3, y3 := at(i + 2)
+		p.catmullrom(x0, y0, x1, y1, x2, y2, x3, y3)
+	}
+}
+
+// catmullrom appends the Bezier equivalent of the Catmull-Rom segment from (x1,y1) to (x2,y2)
+func (p *PDFDoc) catmullrom(x0, y0, x1, y1, x2, y2, x3, y3 float64) {
+	p.CurveTo(x1+(x2-x0)/6, y1+(y2-y0)/6, x2-(x3-x1)/6, y2-(y3-y1)/6, x2, y2)
+}
+
+// Blob draws a colored closed smooth shape through the (x,y) vertices,
+// using a periodic Catmull-Rom spline
+func (p *PDFDoc) Blob(x []float64, y []float64, color string) {
+	if len(x) != len(y) || len(x) < 3 {
+		return
+	}
+	p.closedsplinepath(x, y)
+	p.FillPath(color)
+}
+
+// StrokeBlob draws the outline of a closed smooth shape with specified stroke color and width
+func (p *PDFDoc) StrokeBlob(x []float64, y []float64, sw float64, color string) {
+	if len(x) != len(y) || len(x) < 3 {
+		return
+	}
+	p.closedsplinepath(x, y)
+	p.StrokePath(sw, color)
+}
+
+// closedsplinepath builds a closed Catmull-Rom path through the points, wrapping around
+// at the ends so the curve is smooth at the first point
+func (p *PDFDoc) closedsplinepath(x []float64, y []float64) {
+	n := len(x)
+	at := func(i int) (float64, float64) {
+		i = ((i % n) + n) % n
+		return x[i], y[i]
+	}
+	p.MoveTo(x[0], y[0])
+	for i := 0; i < n; i++ {
+		x0, y0 := at(i - 1)
+		x1, y1 := at(i)
+		x2, y2 := at(i + 1)
+		x3, y3 := at(i + 2)
+		p.catmullrom(x0, y0, x1, y1, x2, y2, x3, y3)
+	}
+	p.ClosePath()
+}
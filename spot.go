@@ -0,0 +1,58 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// spotcolor is a Separation colorspace, with its CMYK alternate.
+type spotcolor struct {
+	name       string // resource name
+	c, m, y, k float64
+}
+
+// DefineSpotColor defines a named spot color (for example "PANTONE 300 C") printed as a
+// separate ink, with the CMYK equivalent (components from 0 to 1) used where the ink is not available.
+// Once defined, use the color with the string "spot(name)", or "spot(name, tint)" for a tint from 0 to 1.
+func (p *PDFDoc) DefineSpotColor(name string, c, m, y, k float64) {
+	c, m, y, k = unit(c), unit(m), unit(y), unit(k)
+	cs := p.colorspaces.add("CS", fmt.Sprintf("[/Separation /%s /DeviceCMYK << /FunctionType 2 /Domain [0 1] /C0 [0 0 0 0] /C1 [%.3f %.3f %.3f %.3f] /N 1 >>]",
+		pdfname(name), c, m, y, k))
+	if p.spots == nil {
+		p.spots = make(map[string]spotcolor)
+	}
+	p.spots[name] = spotcolor{name: cs, c: c, m: m, y: y, k: k}
+}
+
+// spotcolor returns the spot color and tint of a "spot(name)" or "spot(name, tint)" color string,
+// and whether the string is a defined spot color
+func (p *PDFDoc) spotcolor(s string) (spotcolor, float64, bool) {
+	if !strings.HasPrefix(s, "spot(") || !strings.HasSuffix(s, ")") {
+		return spotcolor{}, 0, false
+	}
+	name, tint := s[5:len(s)-1], 1.0
+	if i := strings.LastIndex(name, ","); i >= 0 {
+		arg := strings.TrimSpace(name[i+1:])
+		if _, err := strconv.ParseFloat(strings.TrimSuffix(arg, "%"), 64); err == nil {
+			name, tint = name[:i], unit(cssnumber(arg, 1))
+		}
+	}
+	spot, ok := p.spots[strings.TrimSpace(name)]
+	return spot, tint, ok
+}
+
+// pdfname escapes s for use as a PDF name: characters other than regular printable ones
+// are written as #xx hex codes
+func pdfname(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte("#()<>[]{}/%", c) >= 0 {
+			fmt.Fprintf(&b, "#%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package pdfgen
+
+import "sort"
+
+// Stats describes a document as it has been written, for monitoring: complete after EndDoc.
+type Stats struct {
+	Bytes        int64       // bytes written
+	Objects      int         // PDF objects
+	Pages        int         // pages begun with NewPage
+	Images       int         // image XObjects embedded, including the soft masks of images with alpha
+	ImagesReused int         // image XObjects identical to ones already embedded, and so not embedded again
+	Fonts        []FontStats // fonts used, by name
+}
+
+// FontStats describes the use of a font.
+type FontStats struct {
+	Name   string // PostScript name, as Helvetica
+	Glyphs int    // distinct characters drawn, the glyphs that a subset of the font would hold
+}
+
+// Stats returns the statistics of the document
+func (p *PDFDoc) Stats() Stats {
+	s := Stats{
+		Bytes:        p.out.n,
+		Objects:      p.objectcount,
+		Pages:        p.pagecount,
+		Images:       len(p.images),
+		ImagesReused: p.imagereuses,
+	}
+	for name, glyphs := range p.glyphs {
+		s.Fonts = append(s.Fonts, FontStats{Name: name, Glyphs: len(glyphs)})
+	}
+	sort.Slice(s.Fonts, func(i, j int) bool { return s.Fonts[i].Name < s.Fonts[j].Name })
+	return s
+}
+
+// usefont records the characters of s as drawn in the font with the PostScript name
+func (p *PDFDoc) usefont(name, s string) {
+	if name == "" {
+		return
+	}
+	if p.glyphs == nil {
+		p.glyphs = make(map[string]map[rune]bool)
+	}
+	g := p.glyphs[name]
+	if g == nil {
+		g = make(map[rune]bool)
+		p.glyphs[name] = g
+	}
+	for _, r := range s {
+		g[r] = true
+	}
+}
@@ -0,0 +1,277 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// pen is the current style used by the stateful drawing methods (DrawRect, DrawText, etc.).
+// Closed shapes are filled with the fill color and outlined with the stroke color;
+// lines and curves are stroked. The color "none" turns filling or stroking off.
+type pen struct {
+	fill, stroke string
+	width        float64
+	font         string
+	size         float64
+}
+
+// defaultpen fills with black, without stroking
+var defaultpen = pen{fill: "black", stroke: "none", width: 1, font: "sans", size: 12}
+
+// penops are the style operators last written to the page, so that the stateful drawing
+// methods only write those that change. Empty values are unknown.
+type penops struct {
+	fill, stroke, width, font string
+}
+
+// fillop returns the fill color operator for a drawing method that writes its own,
+// forgetting the operators written by the stateful methods. The alpha of the color,
+// if any, is put into effect, since it cannot be part of the operator.
+func (p *PDFDoc) fillop(color string) string {
+	p.written = penops{}
+	p.fillalpha(color)
+	return p.coloroperator(color, false)
+}
+
+// strokeop returns the stroke color operator for a drawing method that writes its own
+func (p *PDFDoc) strokeop(color string) string {
+	p.written = penops{}
+	p.strokealpha(color)
+	return p.coloroperator(color, true)
+}
+
+// SetFillColor sets the fill color of the stateful drawing methods
+func (p *PDFDoc) SetFillColor(color string) {
+	p.pen.fill = color
+}
+
+// SetStrokeColor sets the stroke color of the stateful drawing methods
+func (p *PDFDoc) SetStrokeColor(color string) {
+	p.pen.stroke = color
+}
+
+// SetLineWidth sets the stroke width of the stateful drawing methods
+func (p *PDFDoc) SetLineWidth(sw float64) {
+	p.pen.width = sw
+}
+
+// SetFont sets the font (sans, serif, mono, symbol) and size of DrawText
+func (p *PDFDoc) SetFont(font string, size float64) {
+	p.pen.font, p.pen.size = font, size
+}
+
+// visible reports whether the color paints
+func visible(color string) bool {
+	return color != "" && color != "none"
+}
+
+// writeop writes the operator op, unless it was the last written of its kind
+func (p *PDFDoc) writeop(last *string, op string) {
+	if *last != op {
+		fmt.Fprintln(p.Writer, op)
+		*last = op
+	}
+}
+
+// paintpath paints the current path in the current style: filled (if closed) and stroked
+func (p *PDFDoc) paintpath(closed bool) {
+	fill := closed && visible(p.pen.fill)
+	stroke := visible(p.pen.stroke)
+	if fill {
+		p.fillalpha(p.pen.fill)
+		p.writeop(&p.written.fill, p.coloroperator(p.pen.fill, false))
+	}
+	if stroke {
+		p.strokealpha(p.pen.stroke)
+		p.writeop(&p.written.stroke, p.coloroperator(p.pen.stroke, true))
+		p.writeop(&p.written.width, fmt.Sprintf("%.2f w", p.pen.width))
+	}
+	switch {
+	case fill && stroke:
+		p.endpath(p.fillrule.fillstrokeop())
+	case fill:
+		p.endpath(p.fillrule.fillop())
+	case stroke:
+		p.endpath("S")
+	default:
+		p.endpath("n")
+	}
+}
+
+// DrawPath paints the current path in the current style
+func (p *PDFDoc) DrawPath() {
+	p.paintpath(true)
+}
+
+// DrawRect draws a rectangle with its lower left at (x,y) in the current style
+func (p *PDFDoc) DrawRect(x, y, w, h float64) {
+	p.rectpath(x, y, w, h)
+	p.paintpath(true)
+}
+
+// DrawRoundRect draws a rectangle with corners of radius r in the current style
+func (p *PDFDoc) DrawRoundRect(x, y, w, h, r float64) {
+	p.roundrectpath(x, y, w, h, r)
+	p.paintpath(true)
+}
+
+// DrawCircle draws a circle in the current style
+func (p *PDFDoc) DrawCircle(x, y, r float64) {
+	p.ellipsepath(x, y, r, r)
+	p.paintpath(true)
+}
+
+// DrawEllipse draws an ellipse in the current style
+func (p *PDFDoc) DrawEllipse(x, y, w, h float64) {
+	p.ellipsepath(x, y, w, h)
+	p.paintpath(true)
+}
+
+// DrawPolygon draws a polygon in the current style
+func (p *PDFDoc) DrawPolygon(x []float64, y []float64) {
+	if p.polygonpath(x, y) {
+		p.paintpath(true)
+	}
+}
+
+// DrawLine strokes a line in the current style
+func (p *PDFDoc) DrawLine(x1, y1, x2, y2 float64) {
+	p.MoveTo(x1, y1)
+	p.LineTo(x2, y2)
+	p.paintpath(false)
+}
+
+// DrawPolyline strokes a polyline in the current style
+func (p *PDFDoc) DrawPolyline(x []float64, y []float64) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	p.MoveTo(x[0], y[0])
+	for i := 1; i < len(x); i++ {
+		p.LineTo(x[i], y[i])
+	}
+	p.paintpath(false)
+}
+
+// DrawCurve strokes a quadratic Bezier curve in the current style
+func (p *PDFDoc) DrawCurve(x1, y1, x2, y2, x3, y3 float64) {
+	p.MoveTo(x1, y1)
+	p.QuadTo(x2, y2, x3, y3)
+	p.paintpath(false)
+}
+
+// DrawArc strokes a circular arc centered at (x,y) from angle1 to angle2 (in degrees) in the current style
+func (p *PDFDoc) DrawArc(x, y, r, angle1, angle2 float64) {
+	a := angle1 * (math.Pi / 180)
+	p.MoveTo(x+r*math.Cos(a), y+r*math.Sin(a))
+	p.arcpath(x, y, r, angle1, angle2)
+	p.paintpath(false)
+}
+
+// DrawText draws text at (x,y) in the current font, size, and fill color
+func (p *PDFDoc) DrawText(x, y float64, s string) {
+	name := p.fontname(p.pen.font)
+	p.usefont(name, s)
+	fmt.Fprint(p.Writer, "BT ")
+	p.writeop(&p.written.font, fmt.Sprintf("/%s %.2f Tf", name, p.pen.size))
+	p.fillalpha(p.pen.fill)
+	p.writeop(&p.written.fill, p.coloroperator(p.pen.fill, false))
+	fmt.Fprintf(p.Writer, "%.2f %.2f Td (%s) Tj ET\n", x, y, pdfstring(s))
+}
+
+// Style describes how a shape is painted by the Styled drawing methods.
+type Style struct {
+	Fill        string    // fill color, none if empty
+	Stroke      string    // stroke color, none if empty
+	StrokeWidth float64   // stroke width, 1 if zero
+	Opacity     float64   // opacity of fill and stroke, opaque if zero
+	Dash        []float64 // dash pattern, solid if empty
+	Radius      float64   // corner radius of rectangles
+}
+
+// styled paints the path made by path in the style s, leaving the current style unchanged
+func (p *PDFDoc) styled(s Style, closed bool, path func()) {
+	p.Push()
+	p.pen.fill, p.pen.stroke = s.Fill, s.Stroke
+	p.pen.width = s.StrokeWidth
+	if p.pen.width == 0 {
+		p.pen.width = 1
+	}
+	if s.Opacity > 0 && s.Opacity < 1 {
+		p.SetOpacity(s.Opacity)
+	}
+	if len(s.Dash) > 0 {
+		p.SetDash(s.Dash, 0)
+	}
+	path()
+	p.paintpath(closed)
+	p.Pop()
+}
+
+// PathStyled paints the current path in the style s
+func (p *PDFDoc) PathStyled(s Style) {
+	p.styled(s, true, func() {})
+}
+
+// RectStyled draws a rectangle, with its lower left at (x,y), in the style s
+func (p *PDFDoc) RectStyled(x, y, w, h float64, s Style) {
+	p.styled(s, true, func() {
+		if s.Radius > 0 {
+			p.roundrectpath(x, y, w, h, s.Radius)
+		} else {
+			p.rectpath(x, y, w, h)
+		}
+	})
+}
+
+// CircleStyled draws a circle in the style s
+func (p *PDFDoc) CircleStyled(x, y, r float64, s Style) {
+	p.styled(s, true, func() { p.ellipsepath(x, y, r, r) })
+}
+
+// EllipseStyled draws an ellipse in the style s
+func (p *PDFDoc) EllipseStyled(x, y, w, h float64, s Style) {
+	p.styled(s, true, func() { p.ellipsepath(x, y, w, h) })
+}
+
+// PolygonStyled draws a polygon in the style s
+func (p *PDFDoc) PolygonStyled(x []float64, y []float64, s Style) {
+	if len(x) != len(y) || len(x) == 0 {
+		return
+	}
+	p.styled(s, true, func() { p.polygonpath(x, y) })
+}
+
+// WedgeStyled draws a pie wedge in the style s
+func (p *PDFDoc) WedgeStyled(cx, cy, r, startAngle, endAngle float64, s Style) {
+	p.styled(s, true, func() { p.wedgepath(cx, cy, r, startAngle, endAngle) })
+}
+
+// LineStyled strokes a line in the style s
+func (p *PDFDoc) LineStyled(x1, y1, x2, y2 float64, s Style) {
+	p.styled(s, false, func() {
+		p.MoveTo(x1, y1)
+		p.LineTo(x2, y2)
+	})
+}
+
+// PolylineStyled strokes a polyline in the style s
+func (p *PDFDoc) PolylineStyled(x []float64, y []float64, s Style) {
+	if len(x) != len(y) || len(x) < 2 {
+		return
+	}
+	p.styled(s, false, func() {
+		p.MoveTo(x[0], y[0])
+		for i := 1; i < len(x); i++ {
+			p.LineTo(x[i], y[i])
+		}
+	})
+}
+
+// CurveStyled strokes a quadratic Bezier curve in the style s
+func (p *PDFDoc) CurveStyled(x1, y1, x2, y2, x3, y3 float64, s Style) {
+	p.styled(s, false, func() {
+		p.MoveTo(x1, y1)
+		p.QuadTo(x2, y2, x3, y3)
+	})
+}
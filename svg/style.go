@@ -0,0 +1,156 @@
+package svg
+
+import (
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// style holds the presentation properties of an element, as inherited and set
+type style struct {
+	fill, stroke  string
+	color         string // the value of currentColor
+	fillopacity   float64
+	strokeopacity float64
+	opacity       float64 // not inherited, but applied to descendants' fills and strokes
+	strokewidth   float64
+	fillrule      string
+	linecap       string
+	linejoin      string
+	dash          []float64
+	display       string
+}
+
+// defaultstyle is the initial style: filled in black, unstroked
+var defaultstyle = style{
+	fill: "black", stroke: "none", color: "black",
+	fillopacity: 1, strokeopacity: 1, opacity: 1, strokewidth: 1,
+	fillrule: "nonzero", linecap: "butt", linejoin: "miter",
+}
+
+// inherit returns the style of an element with the attributes, inheriting from s.
+// Properties in the style attribute take precedence over presentation attributes.
+func (s style) inherit(attrs map[string]string) style {
+	s.display = ""
+	props := make(map[string]string)
+	for k, v := range attrs {
+		props[k] = v
+	}
+	for _, decl := range strings.Split(attrs["style"], ";") {
+		if colon := strings.Index(decl, ":"); colon > 0 {
+			props[strings.TrimSpace(decl[:colon])] = strings.TrimSpace(decl[colon+1:])
+		}
+	}
+	for k, v := range props {
+		v = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), "!important"))
+		if v == "inherit" {
+			continue
+		}
+		switch k {
+		case "color":
+			s.color = v
+		case "fill":
+			s.fill = v
+		case "stroke":
+			s.stroke = v
+		case "fill-opacity":
+			s.fillopacity = opacity(v, s.fillopacity)
+		case "stroke-opacity":
+			s.strokeopacity = opacity(v, s.strokeopacity)
+		case "opacity":
+			s.opacity *= opacity(v, 1)
+		case "stroke-width":
+			s.strokewidth = length(v, s.strokewidth)
+		case "fill-rule":
+			s.fillrule = v
+		case "stroke-linecap":
+			s.linecap = v
+		case "stroke-linejoin":
+			s.linejoin = v
+		case "stroke-dasharray":
+			s.dash = pdfgen.ParseNumbers(v)
+		case "display", "visibility":
+			if v == "none" || v == "hidden" || v == "collapse" {
+				s.display = "none"
+			}
+		}
+	}
+	return s
+}
+
+// opacity returns the value of an opacity property, from 0 to 1, or def if it is invalid
+func opacity(s string, def float64) float64 {
+	pct := strings.HasSuffix(s, "%")
+	v := length(strings.TrimSuffix(s, "%"), def*100)
+	if !pct {
+		v = length(s, def)
+	} else {
+		v /= 100
+	}
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	}
+	return v
+}
+
+// paintcolor returns the pdfgen color of a fill or stroke value, or "" for none.
+// Gradient and pattern references, unsupported, paint nothing.
+func (s style) paintcolor(v string) string {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "", "none", "transparent":
+		return ""
+	case "currentcolor":
+		v = s.color
+	}
+	if strings.HasPrefix(v, "url(") {
+		return ""
+	}
+	return strings.ToLower(v)
+}
+
+// paint paints the current path in the style, or discards it if the style paints nothing
+func (s style) paint(doc *pdfgen.PDFDoc) {
+	fill, stroke := s.paintcolor(s.fill), s.paintcolor(s.stroke)
+	if stroke != "" && s.strokewidth <= 0 {
+		stroke = ""
+	}
+	doc.Push()
+	if fo, so := s.fillopacity*s.opacity, s.strokeopacity*s.opacity; fo < 1 || so < 1 {
+		doc.SetFillStrokeOpacity(fo, so)
+	}
+	if s.fillrule == "evenodd" {
+		doc.SetFillRule(pdfgen.EvenOdd)
+	}
+	if stroke != "" {
+		switch s.linecap {
+		case "round":
+			doc.SetLineCap(pdfgen.RoundCap)
+		case "square":
+			doc.SetLineCap(pdfgen.SquareCap)
+		}
+		switch s.linejoin {
+		case "round":
+			doc.SetLineJoin(pdfgen.RoundJoin)
+		case "bevel":
+			doc.SetLineJoin(pdfgen.BevelJoin)
+		}
+		if len(s.dash) > 0 {
+			doc.SetDash(s.dash, 0)
+		}
+	}
+	if fill == "" {
+		fill = "none"
+	}
+	if stroke == "" {
+		stroke = "none"
+	}
+	doc.SetFillColor(fill)
+	doc.SetStrokeColor(stroke)
+	doc.SetLineWidth(s.strokewidth)
+	doc.DrawPath()
+	doc.Pop()
+}
@@ -0,0 +1,248 @@
+// Package svg draws SVG images on pdfgen pages as vector graphics.
+//
+// A practical subset of SVG is supported: the path, rect, circle, ellipse, line, polyline,
+// and polygon elements, nested in groups, with transforms, and filled and stroked with
+// solid colors. Text, gradients, patterns, clipping, filters, and references (use) are ignored.
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// node is an SVG element, its attributes keyed by local name
+type node struct {
+	name     string
+	attrs    map[string]string
+	children []*node
+}
+
+// Image is a parsed SVG image.
+type Image struct {
+	Width, Height float64    // size of the image, in points
+	viewbox       [4]float64 // min x, min y, width, height, in user units
+	stretch       bool       // preserveAspectRatio none
+	root          *node
+}
+
+// Parse reads an SVG image
+func Parse(r io.Reader) (*Image, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	var stack []*node
+	var root *node
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: make(map[string]string)}
+			for _, a := range t.Attr {
+				n.attrs[a.Name.Local] = a.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if root == nil || root.name != "svg" {
+		return nil, errors.New("svg: no svg element")
+	}
+	img := &Image{root: root}
+	w, h := length(root.attrs["width"], 0), length(root.attrs["height"], 0)
+	if vb := pdfgen.ParseNumbers(root.attrs["viewBox"]); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+		copy(img.viewbox[:], vb)
+		if w == 0 && h == 0 {
+			w, h = vb[2], vb[3]
+		} else if w == 0 {
+			w = h * vb[2] / vb[3]
+		} else if h == 0 {
+			h = w * vb[3] / vb[2]
+		}
+	} else {
+		if w == 0 {
+			w = 300
+		}
+		if h == 0 {
+			h = 150
+		}
+		img.viewbox = [4]float64{0, 0, w, h}
+	}
+	img.stretch = strings.HasPrefix(strings.TrimSpace(root.attrs["preserveAspectRatio"]), "none")
+	// CSS pixels are 3/4 point
+	img.Width, img.Height = w*0.75, h*0.75
+	return img, nil
+}
+
+// Draw draws the image in the w by h rectangle with its lower left at (x,y),
+// scaled to fit and centered, unless the image asks to be stretched to fill
+func (img *Image) Draw(doc *pdfgen.PDFDoc, x, y, w, h float64) {
+	vb := img.viewbox
+	sx, sy := w/vb[2], h/vb[3]
+	if !img.stretch {
+		s := math.Min(sx, sy)
+		x += (w - vb[2]*s) / 2
+		y += (h - vb[3]*s) / 2
+		sx, sy = s, s
+	}
+	doc.Push()
+	// SVG's y axis runs downward from the top left
+	doc.Transform(sx, 0, 0, -sy, x-vb[0]*sx, y+vb[3]*sy+vb[1]*sy)
+	render(doc, img.root, defaultstyle)
+	doc.Pop()
+}
+
+// render draws an element and its children, in the style inherited from its parent
+func render(doc *pdfgen.PDFDoc, n *node, parent style) {
+	switch n.name {
+	case "defs", "title", "desc", "metadata", "style", "symbol", "clipPath", "mask",
+		"linearGradient", "radialGradient", "pattern", "marker", "filter", "text", "use":
+		return
+	}
+	s := parent.inherit(n.attrs)
+	if s.display == "none" {
+		return
+	}
+	transform, hasTransform := n.attrs["transform"]
+	if hasTransform {
+		doc.Push()
+		applytransform(doc, transform)
+		defer doc.Pop()
+	}
+	switch n.name {
+	case "svg", "g", "a", "switch":
+		for _, c := range n.children {
+			render(doc, c, s)
+		}
+		return
+	case "path":
+		if !doc.AppendSVGPath(n.attrs["d"]) {
+			return
+		}
+	case "rect":
+		if !rect(doc, n.attrs) {
+			return
+		}
+	case "circle":
+		r := attr(n.attrs, "r")
+		if r <= 0 {
+			return
+		}
+		ellipse(doc, attr(n.attrs, "cx"), attr(n.attrs, "cy"), r, r)
+	case "ellipse":
+		rx, ry := attr(n.attrs, "rx"), attr(n.attrs, "ry")
+		if rx <= 0 || ry <= 0 {
+			return
+		}
+		ellipse(doc, attr(n.attrs, "cx"), attr(n.attrs, "cy"), rx, ry)
+	case "line":
+		doc.MoveTo(attr(n.attrs, "x1"), attr(n.attrs, "y1"))
+		doc.LineTo(attr(n.attrs, "x2"), attr(n.attrs, "y2"))
+		s.fill = "none"
+	case "polyline", "polygon":
+		pts := pdfgen.ParseNumbers(n.attrs["points"])
+		if len(pts) < 4 {
+			return
+		}
+		doc.MoveTo(pts[0], pts[1])
+		for i := 2; i+1 < len(pts); i += 2 {
+			doc.LineTo(pts[i], pts[i+1])
+		}
+		if n.name == "polygon" {
+			doc.ClosePath()
+		}
+	default:
+		return
+	}
+	s.paint(doc)
+}
+
+// attr returns the numeric value of an attribute, 0 if missing
+func attr(attrs map[string]string, name string) float64 {
+	return length(attrs[name], 0)
+}
+
+// rect builds the path of a rect element, with rounded corners if it has radii,
+// reporting whether it has an area
+func rect(doc *pdfgen.PDFDoc, attrs map[string]string) bool {
+	x, y := attr(attrs, "x"), attr(attrs, "y")
+	w, h := attr(attrs, "width"), attr(attrs, "height")
+	if w <= 0 || h <= 0 {
+		return false
+	}
+	rx, ry := attr(attrs, "rx"), attr(attrs, "ry")
+	if _, ok := attrs["ry"]; !ok {
+		ry = rx
+	}
+	if _, ok := attrs["rx"]; !ok {
+		rx = ry
+	}
+	rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+	if rx <= 0 || ry <= 0 {
+		doc.MoveTo(x, y)
+		doc.LineTo(x+w, y)
+		doc.LineTo(x+w, y+h)
+		doc.LineTo(x, y+h)
+		doc.ClosePath()
+		return true
+	}
+	doc.MoveTo(x+rx, y)
+	doc.LineTo(x+w-rx, y)
+	doc.ArcTo(x+w-rx, y, rx, ry, 0, false, true, x+w, y+ry)
+	doc.LineTo(x+w, y+h-ry)
+	doc.ArcTo(x+w, y+h-ry, rx, ry, 0, false, true, x+w-rx, y+h)
+	doc.LineTo(x+rx, y+h)
+	doc.ArcTo(x+rx, y+h, rx, ry, 0, false, true, x, y+h-ry)
+	doc.LineTo(x, y+ry)
+	doc.ArcTo(x, y+ry, rx, ry, 0, false, true, x+rx, y)
+	doc.ClosePath()
+	return true
+}
+
+// ellipse builds the path of an ellipse centered at (cx,cy) with radii rx and ry
+func ellipse(doc *pdfgen.PDFDoc, cx, cy, rx, ry float64) {
+	doc.MoveTo(cx+rx, cy)
+	doc.ArcTo(cx+rx, cy, rx, ry, 0, false, true, cx-rx, cy)
+	doc.ArcTo(cx-rx, cy, rx, ry, 0, false, true, cx+rx, cy)
+	doc.ClosePath()
+}
+
+// length returns the value of an SVG length in user units (CSS pixels), or def if it is empty
+// or not a number. Percentages are not resolved, and return def.
+func length(s string, def float64) float64 {
+	s = strings.TrimSpace(s)
+	unit := 1.0
+	for _, u := range []struct {
+		suffix string
+		scale  float64
+	}{{"px", 1}, {"pt", 4.0 / 3}, {"pc", 16}, {"in", 96}, {"cm", 96 / 2.54}, {"mm", 96 / 25.4}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSuffix(s, u.suffix), u.scale
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return def
+	}
+	return v * unit
+}
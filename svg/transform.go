@@ -0,0 +1,51 @@
+package svg
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// applytransform concatenates the transforms of a transform attribute, in order:
+// matrix, translate, scale, rotate, skewX, and skewY
+func applytransform(doc *pdfgen.PDFDoc, s string) {
+	for {
+		open := strings.Index(s, "(")
+		close := strings.Index(s, ")")
+		if open < 0 || close < open {
+			return
+		}
+		name := strings.Trim(strings.TrimSpace(s[:open]), ",")
+		name = strings.TrimSpace(name)
+		args := pdfgen.ParseNumbers(s[open+1 : close])
+		s = s[close+1:]
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+		switch name {
+		case "matrix":
+			if len(args) == 6 {
+				doc.Transform(args[0], args[1], args[2], args[3], args[4], args[5])
+			}
+		case "translate":
+			doc.Transform(1, 0, 0, 1, arg(0, 0), arg(1, 0))
+		case "scale":
+			sx := arg(0, 1)
+			doc.Transform(sx, 0, 0, arg(1, sx), 0, 0)
+		case "rotate":
+			// positive angles turn from the x axis toward the y axis, clockwise on screen
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			cos, sin := math.Cos(a), math.Sin(a)
+			doc.Transform(cos, sin, -sin, cos, cx-cos*cx+sin*cy, cy-sin*cx-cos*cy)
+		case "skewX":
+			doc.Transform(1, 0, math.Tan(arg(0, 0)*math.Pi/180), 1, 0, 0)
+		case "skewY":
+			doc.Transform(1, math.Tan(arg(0, 0)*math.Pi/180), 0, 1, 0, 0)
+		}
+	}
+}
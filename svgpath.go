@@ -0,0 +1,248 @@
+package pdfgen
+
+import "strconv"
+
+// pathscanner reads the commands, numbers, and flags of SVG path data
+type pathscanner struct {
+	s string
+	i int
+}
+
+// skip skips white space and commas
+func (sc *pathscanner) skip() {
+	for sc.i < len(sc.s) {
+		switch sc.s[sc.i] {
+		case ' ', '\t', '\n', '\r', ',':
+			sc.i++
+		default:
+			return
+		}
+	}
+}
+
+// number reads a number, which may run into the next without a separator, as in "1.5.5" or "1-2"
+func (sc *pathscanner) number() (float64, bool) {
+	sc.skip()
+	start := sc.i
+	i := sc.i
+	if i < len(sc.s) && (sc.s[i] == '+' || sc.s[i] == '-') {
+		i++
+	}
+	digits, dot := false, false
+	for ; i < len(sc.s); i++ {
+		c := sc.s[i]
+		if c >= '0' && c <= '9' {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+	}
+	if !digits {
+		return 0, false
+	}
+	if i < len(sc.s) && (sc.s[i] == 'e' || sc.s[i] == 'E') {
+		j := i + 1
+		if j < len(sc.s) && (sc.s[j] == '+' || sc.s[j] == '-') {
+			j++
+		}
+		if j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+			for j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+				j++
+			}
+			i = j
+		}
+	}
+	v, err := strconv.ParseFloat(sc.s[start:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	sc.i = i
+	return v, true
+}
+
+// flag reads an arc flag, a single 0 or 1, which need not be separated from what follows
+func (sc *pathscanner) flag() (bool, bool) {
+	sc.skip()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', true
+	}
+	return false, false
+}
+
+// command reads a path command letter, if there is one next
+func (sc *pathscanner) command() (byte, bool) {
+	sc.skip()
+	if sc.i < len(sc.s) {
+		switch c := sc.s[sc.i]; c {
+		case 'M', 'm', 'L', 'l', 'H', 'h', 'V', 'v', 'C', 'c', 'S', 's', 'Q', 'q', 'T', 't', 'A', 'a', 'Z', 'z':
+			sc.i++
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// numbers reads n numbers, reporting whether all were read
+func (sc *pathscanner) numbers(v []float64) bool {
+	for i := range v {
+		n, ok := sc.number()
+		if !ok {
+			return false
+		}
+		v[i] = n
+	}
+	return true
+}
+
+// ParseNumbers returns the numbers of a list separated by spaces or commas, as in SVG
+// point lists, view boxes, and transforms, up to the first item that is not a number
+func ParseNumbers(s string) []float64 {
+	var v []float64
+	sc := pathscanner{s: s}
+	for {
+		n, ok := sc.number()
+		if !ok {
+			return v
+		}
+		v = append(v, n)
+	}
+}
+
+// AppendSVGPath appends the subpaths of SVG path data (the d attribute of a path element)
+// to the current path, reporting whether it appended any. All the commands are supported,
+// absolute and relative: M, L, H, V, C, S, Q, T, A, and Z. As in SVG, the data is followed
+// up to the first error in it.
+func (p *PDFDoc) AppendSVGPath(d string) bool {
+	sc := pathscanner{s: d}
+	var x, y, startx, starty float64 // current point, and start of the subpath
+	var cx, cy float64               // reflected control point of the last curve
+	var last byte
+	started := false
+	var cmd byte
+	for {
+		if c, ok := sc.command(); ok {
+			cmd = c
+		} else if cmd == 0 || cmd == 'Z' || cmd == 'z' {
+			return started
+		} else if sc.skip(); sc.i >= len(sc.s) {
+			return started
+		}
+		if !started && cmd != 'M' && cmd != 'm' {
+			return false
+		}
+		rel := cmd >= 'a'
+		ox, oy := 0.0, 0.0
+		if rel {
+			ox, oy = x, y
+		}
+		var v [7]float64
+		switch cmd {
+		case 'M', 'm':
+			if !sc.numbers(v[:2]) {
+				return started
+			}
+			x, y = ox+v[0], oy+v[1]
+			startx, starty = x, y
+			p.MoveTo(x, y)
+			started = true
+			// further coordinate pairs are implicit line commands
+			cmd = 'L' + (cmd - 'M')
+		case 'L', 'l':
+			if !sc.numbers(v[:2]) {
+				return started
+			}
+			x, y = ox+v[0], oy+v[1]
+			p.LineTo(x, y)
+		case 'H', 'h':
+			if !sc.numbers(v[:1]) {
+				return started
+			}
+			x = ox + v[0]
+			p.LineTo(x, y)
+		case 'V', 'v':
+			if !sc.numbers(v[:1]) {
+				return started
+			}
+			y = oy + v[0]
+			p.LineTo(x, y)
+		case 'C', 'c':
+			if !sc.numbers(v[:6]) {
+				return started
+			}
+			p.CurveTo(ox+v[0], oy+v[1], ox+v[2], oy+v[3], ox+v[4], oy+v[5])
+			cx, cy = ox+v[2], oy+v[3]
+			x, y = ox+v[4], oy+v[5]
+		case 'S', 's':
+			if !sc.numbers(v[:4]) {
+				return started
+			}
+			x1, y1 := x, y
+			if last == 'C' || last == 'S' {
+				x1, y1 = 2*x-cx, 2*y-cy
+			}
+			p.CurveTo(x1, y1, ox+v[0], oy+v[1], ox+v[2], oy+v[3])
+			cx, cy = ox+v[0], oy+v[1]
+			x, y = ox+v[2], oy+v[3]
+		case 'Q', 'q':
+			if !sc.numbers(v[:4]) {
+				return started
+			}
+			p.QuadTo(ox+v[0], oy+v[1], ox+v[2], oy+v[3])
+			cx, cy = ox+v[0], oy+v[1]
+			x, y = ox+v[2], oy+v[3]
+		case 'T', 't':
+			if !sc.numbers(v[:2]) {
+				return started
+			}
+			x1, y1 := x, y
+			if last == 'Q' || last == 'T' {
+				x1, y1 = 2*x-cx, 2*y-cy
+			}
+			p.QuadTo(x1, y1, ox+v[0], oy+v[1])
+			cx, cy = x1, y1
+			x, y = ox+v[0], oy+v[1]
+		case 'A', 'a':
+			var large, sweep, ok bool
+			if !sc.numbers(v[:3]) {
+				return started
+			}
+			if large, ok = sc.flag(); !ok {
+				return started
+			}
+			if sweep, ok = sc.flag(); !ok {
+				return started
+			}
+			if !sc.numbers(v[3:5]) {
+				return started
+			}
+			p.ArcTo(x, y, v[0], v[1], v[2], large, sweep, ox+v[3], oy+v[4])
+			x, y = ox+v[3], oy+v[4]
+		case 'Z', 'z':
+			p.ClosePath()
+			x, y = startx, starty
+		}
+		last = cmd
+		if last >= 'a' {
+			last -= 'a' - 'A'
+		}
+	}
+}
+
+// SVGPath draws SVG path data in the style s, or nothing if the data has no subpaths.
+// The coordinates are those of the page, with y upward; to draw data made for SVG's
+// downward y axis, such as an icon in a 24 by 24 box, with its top left at (x,y) and
+// scaled by k, flip it with a transform:
+//
+//	doc.Push()
+//	doc.Transform(k, 0, 0, -k, x, y)
+//	doc.SVGPath(d, pdfgen.Style{Fill: "black"})
+//	doc.Pop()
+func (p *PDFDoc) SVGPath(d string, s Style) {
+	if !p.AppendSVGPath(d) {
+		return
+	}
+	p.styled(s, true, func() {})
+}
@@ -0,0 +1,36 @@
+package pdfgen
+
+import "testing"
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []float64
+	}{
+		{"", nil},
+		{"1,2 3", []float64{1, 2, 3}},
+		{" 10 , 20\n30\t40 ", []float64{10, 20, 30, 40}},
+		{"1.5.5", []float64{1.5, 0.5}},
+		{"1-2", []float64{1, -2}},
+		{"+1-.5", []float64{1, -0.5}},
+		{"1e2-3", []float64{100, -3}},
+		{"-.5e-1", []float64{-0.05}},
+		{"2E+1", []float64{20}},
+		{"1e", []float64{1}},
+		{"1 x 2", []float64{1}},
+		{"-", nil},
+	}
+	for _, tt := range tests {
+		got := ParseNumbers(tt.s)
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseNumbers(%q) = %v; want %v", tt.s, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseNumbers(%q) = %v; want %v", tt.s, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package table
+
+import (
+	"image"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// Content is drawn in a cell in place of its text: images, paragraphs, nested tables.
+type Content interface {
+	// Height returns the height of the content set in the width, in the cell's style
+	Height(width float64, s Style) float64
+	// Draw draws the content set in the width, with its top left at (x,y)
+	Draw(doc *pdfgen.PDFDoc, x, y, width float64, s Style)
+}
+
+// Paragraphs are blocks of text, each wrapped to the cell width, set apart by half a line.
+type Paragraphs []string
+
+// lines returns the wrapped lines of the paragraphs, with an empty line between them
+func (ps Paragraphs) lines(width float64, s Style) [][]string {
+	lines := make([][]string, len(ps))
+	for i, para := range ps {
+		lines[i] = pdfgen.WrapText(strings.TrimSpace(para), s.Font, s.FontSize, width)
+	}
+	return lines
+}
+
+// Height returns the height of the paragraphs set in the width
+func (ps Paragraphs) Height(width float64, s Style) float64 {
+	h := 0.0
+	for i, lines := range ps.lines(width, s) {
+		if i > 0 {
+			h += leading(s) / 2
+		}
+		h += float64(len(lines)) * leading(s)
+	}
+	return h
+}
+
+// Draw draws the paragraphs, left aligned
+func (ps Paragraphs) Draw(doc *pdfgen.PDFDoc, x, y, width float64, s Style) {
+	s.Padding = 0
+	for _, lines := range ps.lines(width, s) {
+		drawlines(doc, lines, x, y, width, s, Left)
+		y -= float64(len(lines))*leading(s) + leading(s)/2
+	}
+}
+
+// Image is an image in a cell, sized W by H, or naturally at 72 dpi where they are zero,
+// and scaled down to fit the cell width.
+type Image struct {
+	Image image.Image
+	W, H  float64
+}
+
+// size returns the size of the image set in the width
+func (im Image) size(width float64) (float64, float64) {
+	b := im.Image.Bounds()
+	w, h := im.W, im.H
+	switch {
+	case w == 0 && h == 0:
+		w, h = float64(b.Dx()), float64(b.Dy())
+	case w == 0:
+		w = h * float64(b.Dx()) / float64(b.Dy())
+	case h == 0:
+		h = w * float64(b.Dy()) / float64(b.Dx())
+	}
+	if w > width {
+		w, h = width, h*width/w
+	}
+	return w, h
+}
+
+// Height returns the height of the image set in the width
+func (im Image) Height(width float64, s Style) float64 {
+	if im.Image == nil || im.Image.Bounds().Empty() {
+		return 0
+	}
+	_, h := im.size(width)
+	return h
+}
+
+// Draw places the image
+func (im Image) Draw(doc *pdfgen.PDFDoc, x, y, width float64, s Style) {
+	if im.Image == nil || im.Image.Bounds().Empty() {
+		return
+	}
+	w, h := im.size(width)
+	doc.ImageGo(x, y-h, im.Image, pdfgen.ImageOptions{Width: w, Height: h})
+}
+
+// Nested is a table within a cell.
+type Nested struct {
+	Table *Table
+}
+
+// Height returns the height of the nested table
+func (n Nested) Height(width float64, s Style) float64 {
+	return n.Table.Height()
+}
+
+// Draw draws the nested table
+func (n Nested) Draw(doc *pdfgen.PDFDoc, x, y, width float64, s Style) {
+	n.Table.Draw(doc, x, y)
+}
+
+// Glyph is a small drawing in a cell, such as a sparkline from the chart package:
+// Render draws it in the w by h rectangle with its lower left at (x,y).
+type Glyph struct {
+	W, H   float64 // size of the drawing; W, if zero, and H, if zero, are the cell width and a line of text
+	Render func(doc *pdfgen.PDFDoc, x, y, w, h float64)
+}
+
+// size returns the size of the glyph set in the width
+func (g Glyph) size(width float64, s Style) (float64, float64) {
+	w, h := g.W, g.H
+	if w == 0 || w > width {
+		w = width
+	}
+	if h == 0 {
+		h = s.FontSize
+	}
+	return w, h
+}
+
+// Height returns the height of the glyph
+func (g Glyph) Height(width float64, s Style) float64 {
+	_, h := g.size(width, s)
+	return h
+}
+
+// Draw renders the glyph
+func (g Glyph) Draw(doc *pdfgen.PDFDoc, x, y, width float64, s Style) {
+	if g.Render == nil {
+		return
+	}
+	w, h := g.size(width, s)
+	g.Render(doc, x, y-h, w, h)
+}
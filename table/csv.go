@@ -0,0 +1,108 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ajstarks/pdfgen"
+)
+
+// CSVOptions describes how CSV data is read and set as a table.
+type CSVOptions struct {
+	Comma  rune    // field delimiter, default ','; '\t' for TSV
+	Width  float64 // width of the table, default 540
+	Header bool    // the first record is a header, repeated on each page
+	Style  Style   // style of the cells
+	Zebra  string  // background of alternate body rows; none if empty
+
+	HeaderStyle Style // style of the header cells, default on a lightgray background
+}
+
+// defaults fills in the default values
+func (o CSVOptions) defaults() CSVOptions {
+	if o.Comma == 0 {
+		o.Comma = ','
+	}
+	if o.Width == 0 {
+		o.Width = 540
+	}
+	if o.HeaderStyle == (Style{}) {
+		o.HeaderStyle.Background = "lightgray"
+	}
+	return o
+}
+
+// FromCSV reads CSV (or, with a tab delimiter, TSV) records from r and returns them
+// as a table. The columns share the width in proportion to their widest text,
+// and columns of numbers are right aligned.
+func FromCSV(r io.Reader, opts CSVOptions) (*Table, error) {
+	opts = opts.defaults()
+	cr := csv.NewReader(r)
+	cr.Comma = opts.Comma
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, rec := range records {
+		if len(rec) > n {
+			n = len(rec)
+		}
+	}
+	t := &Table{Columns: make([]Column, n), Style: opts.Style, Zebra: opts.Zebra}
+	style := t.Style.merge(defaultstyle)
+	numeric := make([]bool, n)
+	for i := range numeric {
+		numeric[i] = true
+	}
+	natural := make([]float64, n)
+	total := 0.0
+	for i, rec := range records {
+		head := opts.Header && i == 0
+		s := style
+		if head {
+			s = opts.HeaderStyle.merge(style)
+		}
+		for j, field := range rec {
+			field = strings.TrimSpace(field)
+			if w := pdfgen.TextWidth(field, s.Font, s.FontSize) + 2*s.Padding; w > natural[j] {
+				natural[j] = w
+			}
+			if !head && field != "" && !isnumber(field) {
+				numeric[j] = false
+			}
+		}
+		row := t.AddRow(rec...)
+		if head {
+			row.Style = opts.HeaderStyle
+		}
+	}
+	for _, w := range natural {
+		total += w
+	}
+	for j := range t.Columns {
+		t.Columns[j].Width = opts.Width / float64(n)
+		if total > 0 {
+			t.Columns[j].Width = opts.Width * natural[j] / total
+		}
+		if numeric[j] {
+			t.Columns[j].Align = Right
+		}
+	}
+	if opts.Header && len(records) > 0 {
+		t.Header = 1
+	}
+	return t, nil
+}
+
+// isnumber reports whether the field is a number, allowing thousands separators,
+// a leading currency sign and a trailing percent sign
+func isnumber(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "$")
+	s = strings.TrimSuffix(strings.Replace(s, ",", "", -1), "%")
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
@@ -0,0 +1,28 @@
+package table
+
+import "testing"
+
+func TestIsNumber(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"42", true},
+		{"-3.5", true},
+		{"1,234,567", true},
+		{"$19.99", true},
+		{"-$5", true},
+		{"12%", true},
+		{"1e3", true},
+		{"", false},
+		{"abc", false},
+		{"12 apples", false},
+		{"$", false},
+		{"%", false},
+	}
+	for _, tt := range tests {
+		if got := isnumber(tt.s); got != tt.want {
+			t.Errorf("isnumber(%q) = %v; want %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Package table lays out and draws tables on pdfgen documents.
+package table
+
+import "github.com/ajstarks/pdfgen"
+
+// Align is the horizontal alignment of text in a column.
+type Align int
+
+const (
+	// Left aligns text at the left of the cell, the default.
+	Left Align = iota
+	// Center centers text in the cell.
+	Center
+	// Right aligns text at the right of the cell.
+	Right
+)
+
+// Column describes a table column.
+type Column struct {
+	Width float64 // width of the column
+	Align Align   // alignment of the text in the column
+}
+
+// Style describes how cells are drawn. Zero fields are inherited: a cell's
+// style from its row's, and a row's from the table's.
+type Style struct {
+	Font        string  // font of the text, default sans
+	FontSize    float64 // size of the text, default 10
+	Color       string  // color of the text, default black
+	Background  string  // fill color of the cell; none if empty
+	Padding     float64 // space between the cell edges and the text, default 4
+	Border      string  // color of the cell border; none if empty
+	BorderWidth float64 // width of the cell border, default 0.5
+}
+
+// merge returns the style with its zero fields taken from base
+func (s Style) merge(base Style) Style {
+	if s.Font == "" {
+		s.Font = base.Font
+	}
+	if s.FontSize == 0 {
+		s.FontSize = base.FontSize
+	}
+	if s.Color == "" {
+		s.Color = base.Color
+	}
+	if s.Background == "" {
+		s.Background = base.Background
+	}
+	if s.Padding == 0 {
+		s.Padding = base.Padding
+	}
+	if s.Border == "" {
+		s.Border = base.Border
+	}
+	if s.BorderWidth == 0 {
+		s.BorderWidth = base.BorderWidth
+	}
+	return s
+}
+
+// defaultstyle is the style of a table with no style of its own
+var defaultstyle = Style{Font: "sans", FontSize: 10, Color: "black", Padding: 4, BorderWidth: 0.5}
+
+// Cell is a table cell.
+type Cell struct {
+	Text    string  // text of the cell, wrapped to its width; newlines begin new lines
+	Content Content // content drawn in place of the text, if any
+	Style   Style   // style of the cell, over the style of its row
+
+	ColSpan int // number of columns spanned, default 1
+	RowSpan int // number of rows spanned, default 1
+}
+
+// Row is a table row.
+type Row struct {
+	Cells []Cell
+	Style Style // style of the row's cells, over the style of the table
+
+	KeepWithNext bool // keep the row on the same page as the next one
+}
+
+// Table is a table of rows in columns.
+type Table struct {
+	Columns []Column
+	Rows    []*Row
+	Style   Style // style of all cells
+
+	Header int    // number of leading rows that head the table, repeated on each page
+	Zebra  string // background of alternate body rows that have none of their own; none if empty
+}
+
+// New returns a table with the columns
+func New(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow adds a row of cells with the text, returning the row, so that its style may be set
+func (t *Table) AddRow(text ...string) *Row {
+	r := &Row{Cells: make([]Cell, len(text))}
+	for i, s := range text {
+		r.Cells[i].Text = s
+	}
+	t.Rows = append(t.Rows, r)
+	return r
+}
+
+// Width returns the width of the table: the sum of the column widths
+func (t *Table) Width() float64 {
+	w := 0.0
+	for _, c := range t.Columns {
+		w += c.Width
+	}
+	return w
+}
+
+// leading is the distance between the baselines of lines of text
+func leading(s Style) float64 {
+	return s.FontSize * 1.2
+}
+
+// slot is a cell in its place in the table
+type slot struct {
+	cell       *Cell // nil for an empty place at the end of a short row
+	style      Style
+	row, col   int
+	rows, cols int
+	width      float64
+}
+
+// grid is the layout of a table: its cells in place, and the heights of its rows
+type grid struct {
+	slots   []slot
+	heights []float64
+	joined  []bool // the row is joined to the next by a cell spanning both
+}
+
+// layout places the cells of the table, left to right past cells spanning from rows above,
+// and finds the row heights from the cells' text and content
+func (t *Table) layout() grid {
+	n := len(t.Columns)
+	g := grid{heights: make([]float64, len(t.Rows)), joined: make([]bool, len(t.Rows))}
+	base := t.Style.merge(defaultstyle)
+	below := make([]int, n) // rows still taken by cells from above, by column
+	nh := t.header()
+	for i, r := range t.Rows {
+		last := len(t.Rows)
+		if i < nh {
+			last = nh // cells do not span from the header into the body
+		}
+		rs := r.Style.merge(base)
+		col := 0
+		place := func(c *Cell, s Style, cols, rows int) {
+			sl := slot{cell: c, style: s, row: i, col: col, rows: rows, cols: cols}
+			for k := col; k < col+cols; k++ {
+				sl.width += t.Columns[k].Width
+				below[k] = rows
+			}
+			for k := i; k < i+rows-1; k++ {
+				g.joined[k] = true
+			}
+			g.slots = append(g.slots, sl)
+			col += cols
+		}
+		for j := range r.Cells {
+			for col < n && below[col] > 0 {
+				col++
+			}
+			if col >= n {
+				break
+			}
+			c := &r.Cells[j]
+			cols := 1
+			for cols < c.ColSpan && col+cols < n && below[col+cols] == 0 {
+				cols++
+			}
+			rows := c.RowSpan
+			if rows < 1 {
+				rows = 1
+			}
+			if i+rows > last {
+				rows = last - i
+			}
+			place(c, c.Style.merge(rs), cols, rows)
+		}
+		for col < n {
+			if below[col] > 0 {
+				col++
+				continue
+			}
+			place(nil, rs, 1, 1)
+		}
+		for k := range below {
+			if below[k] > 0 {
+				below[k]--
+			}
+		}
+	}
+	// single rows first, then rows spanned, growing the last row of the span to fit
+	for _, sl := range g.slots {
+		if sl.rows == 1 {
+			if h := sl.height(); h > g.heights[sl.row] {
+				g.heights[sl.row] = h
+			}
+		}
+	}
+	for _, sl := range g.slots {
+		if sl.rows > 1 {
+			if more := sl.height() - g.span(sl.row, sl.row+sl.rows); more > 0 {
+				g.heights[sl.row+sl.rows-1] += more
+			}
+		}
+	}
+	return g
+}
+
+// span returns the height of rows from through to-1
+func (g grid) span(from, to int) float64 {
+	h := 0.0
+	for _, rh := range g.heights[from:to] {
+		h += rh
+	}
+	return h
+}
+
+// lines returns the text of the slot, wrapped to its width
+func (sl slot) lines() []string {
+	if sl.cell == nil || sl.cell.Text == "" {
+		return nil
+	}
+	s := sl.style
+	return pdfgen.WrapText(sl.cell.Text, s.Font, s.FontSize, sl.width-2*s.Padding)
+}
+
+// height returns the height needed by the slot's text or content
+func (sl slot) height() float64 {
+	s := sl.style
+	if sl.cell != nil && sl.cell.Content != nil {
+		return sl.cell.Content.Height(sl.width-2*s.Padding, s) + 2*s.Padding
+	}
+	lines := len(sl.lines())
+	if lines == 0 {
+		lines = 1
+	}
+	return float64(lines)*leading(s) + 2*s.Padding
+}
+
+// Height returns the height of the table
+func (t *Table) Height() float64 {
+	g := t.layout()
+	return g.span(0, len(g.heights))
+}
+
+// stripe returns the zebra background of row i, if any
+func (t *Table) stripe(i int) string {
+	if i < t.Header || (i-t.Header)%2 == 0 {
+		return ""
+	}
+	return t.Zebra
+}
+
+// header returns the number of header rows
+func (t *Table) header() int {
+	if t.Header > len(t.Rows) {
+		return len(t.Rows)
+	}
+	return t.Header
+}
+
+// Draw draws the table with its top left at (x,y), and returns the y of its bottom
+func (t *Table) Draw(doc *pdfgen.PDFDoc, x, y float64) float64 {
+	return t.drawrows(doc, t.layout(), x, y, 0, len(t.Rows))
+}
+
+// DrawPages draws the table with its top left at (x,y) on the current page, continuing
+// on following pages when the rows reach bottom. Each following page is begun with the
+// header rows at top; rows kept with the next, or joined by cells spanning them, are moved
+// to a following page together, unless they would not fit on a page of their own.
+// The last page is left open, and the y of the bottom of the table on it is returned.
+// Since the document's pages are counted ahead, see Pages for the number of pages taken.
+func (t *Table) DrawPages(doc *pdfgen.PDFDoc, x, y, top, bottom float64) float64 {
+	g := t.layout()
+	nh := t.header()
+	y = t.drawrows(doc, g, x, y, 0, nh)
+	pages := t.paginate(g, y, top, bottom)
+	start := nh
+	for i := nh + 1; i <= len(t.Rows); i++ {
+		if i < len(t.Rows) && pages[i-nh] == pages[start-nh] {
+			continue
+		}
+		y = t.drawrows(doc, g, x, y, start, i)
+		if i < len(t.Rows) {
+			doc.EndPage()
+			doc.NewPage(doc.Page() + 1)
+			y = t.drawrows(doc, g, x, top, 0, nh)
+		}
+		start = i
+	}
+	return y
+}
+
+// Pages returns the number of pages taken by DrawPages from y, including the first
+func (t *Table) Pages(y, top, bottom float64) int {
+	g := t.layout()
+	pages := t.paginate(g, y-g.span(0, t.header()), top, bottom)
+	if len(pages) == 0 {
+		return 1
+	}
+	return pages[len(pages)-1] + 1
+}
+
+// paginate returns the page of each body row, counted from the first,
+// placing them from y, below the header on the first page
+func (t *Table) paginate(g grid, y, top, bottom float64) []int {
+	nh := t.header()
+	hh := g.span(0, nh)
+	pages := make([]int, 0, len(t.Rows)-nh)
+	page, fresh := 0, true // fresh: no body rows on the page yet
+	for i := nh; i < len(t.Rows); {
+		j := i + 1
+		for j < len(t.Rows) && (t.Rows[j-1].KeepWithNext || g.joined[j-1]) {
+			j++
+		}
+		if !fresh && y-g.span(i, j) < bottom {
+			page, y, fresh = page+1, top-hh, true
+		}
+		for ; i < j; i++ {
+			h := g.heights[i]
+			if !fresh && y-h < bottom {
+				page, y = page+1, top-hh
+			}
+			pages = append(pages, page)
+			y, fresh = y-h, false
+		}
+	}
+	return pages
+}
+
+// drawrows draws the cells of rows from through to-1, with the top left of the first at (x,y),
+// and returns the y of the bottom of the last
+func (t *Table) drawrows(doc *pdfgen.PDFDoc, g grid, x, y float64, from, to int) float64 {
+	tops := make([]float64, to-from+1)
+	tops[0] = y
+	for i := from; i < to; i++ {
+		tops[i-from+1] = tops[i-from] - g.heights[i]
+	}
+	lefts := make([]float64, len(t.Columns))
+	for i := 1; i < len(lefts); i++ {
+		lefts[i] = lefts[i-1] + t.Columns[i-1].Width
+	}
+	for _, sl := range g.slots {
+		if sl.row >= from && sl.row < to {
+			t.drawslot(doc, sl, x+lefts[sl.col], tops[sl.row-from], g.span(sl.row, sl.row+sl.rows))
+		}
+	}
+	return tops[len(tops)-1]
+}
+
+// drawslot draws a cell in its place, with its top left at (x,y) and height h
+func (t *Table) drawslot(doc *pdfgen.PDFDoc, sl slot, x, y, h float64) {
+	s, w := sl.style, sl.width
+	if s.Background == "" {
+		s.Background = t.stripe(sl.row)
+	}
+	if s.Background != "" {
+		doc.Rect(x, y-h, w, h, s.Background)
+	}
+	if sl.cell != nil && sl.cell.Content != nil {
+		sl.cell.Content.Draw(doc, x+s.Padding, y-s.Padding, w-2*s.Padding, s)
+	} else {
+		drawlines(doc, sl.lines(), x, y, w, s, t.Columns[sl.col].Align)
+	}
+	if s.Border != "" {
+		doc.StrokeRect(x, y-h, w, h, s.BorderWidth, s.Border)
+	}
+}
+
+// drawlines draws lines of text aligned in the width, the first a padding below y
+func drawlines(doc *pdfgen.PDFDoc, lines []string, x, y, w float64, s Style, align Align) {
+	ty := y - s.Padding - s.FontSize
+	for _, line := range lines {
+		tx := x + s.Padding
+		switch align {
+		case Center:
+			tx = x + (w-pdfgen.TextWidth(line, s.Font, s.FontSize))/2
+		case Right:
+			tx = x + w - s.Padding - pdfgen.TextWidth(line, s.Font, s.FontSize)
+		}
+		doc.Text(tx, ty, line, s.Font, s.FontSize, s.Color)
+		ty -= leading(s)
+	}
+}
@@ -0,0 +1,43 @@
+package table
+
+import "testing"
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name      string
+		heights   []float64
+		keep      []int // rows kept with the next
+		header    int
+		y, bottom float64
+		want      []int
+	}{
+		{"one page", []float64{10, 10, 10}, nil, 0, 100, 70, []int{0, 0, 0}},
+		{"breaks", []float64{10, 10, 10, 10, 10}, nil, 0, 100, 70, []int{0, 0, 0, 1, 1}},
+		{"below a header", []float64{20, 10, 10, 10, 10}, nil, 1, 80, 55, []int{0, 0, 1, 1}},
+		{"kept with the next", []float64{10, 10, 10, 10}, []int{1}, 0, 100, 75, []int{0, 1, 1, 2}},
+		{"tall row on a fresh page", []float64{50}, nil, 0, 100, 70, []int{0}},
+		{"tall row moved", []float64{10, 50}, nil, 0, 100, 70, []int{0, 1}},
+		{"header only", []float64{20}, nil, 1, 80, 55, []int{}},
+	}
+	for _, tt := range tests {
+		tb := &Table{Header: tt.header}
+		g := grid{heights: tt.heights, joined: make([]bool, len(tt.heights))}
+		for range tt.heights {
+			tb.Rows = append(tb.Rows, &Row{})
+		}
+		for _, k := range tt.keep {
+			tb.Rows[k].KeepWithNext = true
+		}
+		got := tb.paginate(g, tt.y, 100, tt.bottom)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: pages %v; want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: pages %v; want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
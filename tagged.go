@@ -0,0 +1,78 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A tagged PDF has a logical structure alongside its content, for assistive technology
+// and reflow. Content belonging to a structure element is marked on the page with
+// a marked-content identifier (MCID), which the element refers to.
+
+// structelem is an element of the document structure.
+type structelem struct {
+	tag  string // structure type, for example Figure
+	alt  string // alternate description
+	page int    // page number of the content
+	mcid int    // marked-content identifier of the content on its page
+}
+
+// SetTagged sets whether the document is tagged, with a logical structure
+// holding, for example, the alternate text of images. Call it before the first page.
+func (p *PDFDoc) SetTagged(tagged bool) {
+	p.tagged = tagged
+}
+
+// beginmarked begins content belonging to a new structure element, with the alternate description
+func (p *PDFDoc) beginmarked(tag, alt string) {
+	if !p.tagged {
+		return
+	}
+	p.structure = append(p.structure, structelem{tag: tag, alt: alt, page: p.page, mcid: p.mcid})
+	fmt.Fprintf(p.Writer, "/%s << /MCID %d >> BDC\n", tag, p.mcid)
+	p.mcid++
+}
+
+// endmarked ends the content begun with beginmarked
+func (p *PDFDoc) endmarked() {
+	if p.tagged {
+		fmt.Fprintln(p.Writer, "EMC")
+	}
+}
+
+// pagestructure returns the page dictionary entry linking a page to the structure
+func (p *PDFDoc) pagestructure(n int) string {
+	if !p.tagged {
+		return ""
+	}
+	return fmt.Sprintf(" /StructParents %d", n-1)
+}
+
+// structtree adds the structure tree objects, returning the catalog entries referring to them
+func (p *PDFDoc) structtree() string {
+	if !p.tagged {
+		return ""
+	}
+	root, doc := p.reserveobject(), p.reserveobject()
+	var kids []string
+	parents := map[int][]string{}
+	for _, e := range p.structure {
+		def := fmt.Sprintf("<< /Type /StructElem /S /%s /P %d 0 R /Pg %d 0 R /K %d", e.tag, doc, 2*e.page+1, e.mcid)
+		if e.alt != "" {
+			def += fmt.Sprintf(" /Alt (%s)", pdfstring(e.alt))
+		}
+		ref := fmt.Sprintf("%d 0 R", p.addobject(def+" >>"))
+		kids = append(kids, ref)
+		parents[e.page] = append(parents[e.page], ref)
+	}
+	var nums []string
+	for page := 1; page <= p.npages; page++ {
+		if refs, ok := parents[page]; ok {
+			nums = append(nums, fmt.Sprintf("%d [%s]", page-1, strings.Join(refs, " ")))
+		}
+	}
+	tree := p.addobject(fmt.Sprintf("<< /Nums [%s] >>", strings.Join(nums, " ")))
+	p.defineobject(doc, fmt.Sprintf("<< /Type /StructElem /S /Document /P %d 0 R /K [%s] >>", root, strings.Join(kids, " ")))
+	p.defineobject(root, fmt.Sprintf("<< /Type /StructTreeRoot /K %d 0 R /ParentTree %d 0 R /ParentTreeNextKey %d >>", doc, tree, p.npages))
+	return fmt.Sprintf("/MarkInfo << /Marked true >> /StructTreeRoot %d 0 R ", root)
+}
@@ -0,0 +1,77 @@
+package pdfgen
+
+import "strings"
+
+// Character widths for the printable ASCII characters (32-126) of the standard fonts,
+// in thousandths of the font size, from the Adobe Font Metrics files.
+var helveticawidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 222, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	222, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var timeswidths = [95]int{
+	250, 333, 408, 500, 500, 833, 778, 333, 333, 333, 500, 564, 250, 333, 250, 278,
+	500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+	921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+	556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+	333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+	500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+}
+
+// charwidth returns the width of r in the named font, in thousandths of the font size.
+// Characters without metrics are given an average width.
+func charwidth(font string, r rune) int {
+	switch fontmap[font] {
+	case "Courier":
+		return 600
+	case "Times-Roman":
+		if r >= 32 && r <= 126 {
+			return timeswidths[r-32]
+		}
+		return 500
+	case "Zapf-Dingbats":
+		return 788
+	default:
+		if r >= 32 && r <= 126 {
+			return helveticawidths[r-32]
+		}
+		return 556
+	}
+}
+
+// TextWidth returns the width of s, set in the named font (sans, serif, mono, symbol) at size
+func TextWidth(s, font string, size float64) float64 {
+	w := 0
+	for _, r := range s {
+		w += charwidth(font, r)
+	}
+	return float64(w) * size / 1000
+}
+
+// WrapText breaks s into lines no wider than width, when set in the named font at size.
+// Lines are broken at spaces, and at newlines in s; words wider than width are not broken.
+func WrapText(s, font string, size, width float64) []string {
+	var lines []string
+	space := TextWidth(" ", font, size)
+	for _, para := range strings.Split(s, "\n") {
+		line, lw := "", 0.0
+		for _, word := range strings.Fields(para) {
+			ww := TextWidth(word, font, size)
+			if line != "" && lw+space+ww > width {
+				lines = append(lines, line)
+				line, lw = "", 0
+			}
+			if line == "" {
+				line, lw = word, ww
+			} else {
+				line, lw = line+" "+word, lw+space+ww
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
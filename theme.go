@@ -0,0 +1,122 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Theme is a set of colors and fonts that drawing calls may refer to by role,
+// in place of a color string or font name:
+//
+//	"background", "foreground", "muted", "accent"  the colors of the theme
+//	"series1", "series2", ...                      the series colors, repeating if there are fewer
+//	"body", "heading"                              the fonts of the theme
+//
+// Color roles take an opacity suffix like other colors ("accent/50"), and
+// "background" is white when the theme leaves the page unpainted.
+type Theme struct {
+	Background  string   // page color, painted at each new page; none if empty
+	Foreground  string   // text and line color
+	Muted       string   // secondary text, grid lines and the like
+	Accent      string   // highlights
+	Series      []string // colors of data series
+	Font        string   // body font
+	HeadingFont string   // heading font
+}
+
+// LightTheme is dark text on the (unpainted) white page
+var LightTheme = Theme{
+	Foreground:  "black",
+	Muted:       "gray",
+	Accent:      "steelblue",
+	Series:      []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"},
+	Font:        "sans",
+	HeadingFont: "sans",
+}
+
+// DarkTheme is light text on a dark page
+var DarkTheme = Theme{
+	Background:  "#1e1e1e",
+	Foreground:  "#eeeeee",
+	Muted:       "#888888",
+	Accent:      "orange",
+	Series:      []string{"#8cb4e0", "#ffb26b", "#ff8a8c", "#9ed9d4", "#8fd17f", "#ffe17a", "#d6a8cb", "#ffc4ca", "#c9a58d", "#dcd4d0"},
+	Font:        "sans",
+	HeadingFont: "sans",
+}
+
+// SetTheme sets the theme of subsequent pages and drawing, and sets the
+// fill color and font used by DrawText to the theme's foreground and body font
+func (p *PDFDoc) SetTheme(t Theme) {
+	p.theme = t
+	p.pen.fill, p.pen.font = "foreground", "body"
+}
+
+// Theme returns the document theme
+func (p *PDFDoc) Theme() Theme {
+	return p.theme
+}
+
+// SeriesColor returns the color of data series n, counting from 1
+func (t Theme) SeriesColor(n int) string {
+	if len(t.Series) == 0 {
+		return t.Foreground
+	}
+	if n < 1 {
+		n = 1
+	}
+	return t.Series[(n-1)%len(t.Series)]
+}
+
+// rolecolor returns the theme color for a color role, or s.
+// A role may carry an opacity suffix ("accent/50"), kept on the theme color
+func (p *PDFDoc) rolecolor(s string) string {
+	role, suffix := s, ""
+	if slash := strings.Index(s, "/"); slash > 0 {
+		role, suffix = s[:slash], s[slash:]
+	}
+	var c string
+	switch role {
+	case "background":
+		c = p.theme.Background
+		if c == "" {
+			c = "white" // the unpainted page
+		}
+	case "foreground":
+		c = p.theme.Foreground
+	case "muted":
+		c = p.theme.Muted
+	case "accent":
+		c = p.theme.Accent
+	default:
+		n, err := strconv.Atoi(strings.TrimPrefix(role, "series"))
+		if !strings.HasPrefix(role, "series") || err != nil {
+			return s
+		}
+		c = p.theme.SeriesColor(n)
+	}
+	return c + suffix
+}
+
+// fontname returns the PDF font name of a font, or a theme font role
+func (p *PDFDoc) fontname(font string) string {
+	switch font {
+	case "body":
+		font = p.theme.Font
+	case "heading":
+		font = p.theme.HeadingFont
+	}
+	name, ok := fontmap[font]
+	if !ok {
+		p.problem(UnknownFont, p.page, "unknown font %q", font)
+	}
+	return name
+}
+
+// background paints the page with the theme background color
+func (p *PDFDoc) background() {
+	if visible(p.theme.Background) {
+		fmt.Fprintf(p.Writer, "q %s 0 0 %v %v re f Q\n", p.coloroperator(p.theme.Background, false), p.width, p.height)
+	}
+}
@@ -0,0 +1,63 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// The transforms modify the coordinate system of subsequent drawing on the current page.
+// They accumulate; scope them with Push and Pop to apply them to a group of drawing calls.
+
+// matrix is an affine transformation matrix [a b c d e f]
+type matrix [6]float64
+
+// identity is the identity matrix
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// multiply returns the matrix m followed by n
+func (m matrix) multiply(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// concat concatenates the matrix [a b c d e f] to the current transformation matrix
+func (p *PDFDoc) concat(a, b, c, d, e, f float64) {
+	fmt.Fprintf(p.Writer, cmfmt, a, b, c, d, e, f)
+	p.ctm = matrix{a, b, c, d, e, f}.multiply(p.ctm)
+}
+
+// Transform concatenates the affine matrix [a b c d e f] to the current transformation,
+// mapping (x,y) to (a*x + c*y + e, b*x + d*y + f), as SVG's matrix(a,b,c,d,e,f).
+func (p *PDFDoc) Transform(a, b, c, d, e, f float64) {
+	p.concat(a, b, c, d, e, f)
+}
+
+// Translate moves the origin to (tx,ty)
+func (p *PDFDoc) Translate(tx, ty float64) {
+	p.concat(1, 0, 0, 1, tx, ty)
+}
+
+// Rotate rotates the coordinate system about (x,y) by angle degrees, counterclockwise
+func (p *PDFDoc) Rotate(angle, x, y float64) {
+	a := angle * (math.Pi / 180)
+	sin, cos := math.Sin(a), math.Cos(a)
+	p.concat(cos, sin, -sin, cos, x-x*cos+y*sin, y-x*sin-y*cos)
+}
+
+// Scale scales the coordinate system about (x,y), by sx horizontally and sy vertically
+func (p *PDFDoc) Scale(sx, sy, x, y float64) {
+	p.concat(sx, 0, 0, sy, x-sx*x, y-sy*y)
+}
+
+// Shear skews the coordinate system about (x,y): ax degrees along the x axis, and ay degrees along the y axis
+func (p *PDFDoc) Shear(ax, ay, x, y float64) {
+	tx := math.Tan(ax * (math.Pi / 180))
+	ty := math.Tan(ay * (math.Pi / 180))
+	p.concat(1, ty, tx, 1, -tx*y, -ty*x)
+}
@@ -0,0 +1,317 @@
+package pdfgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ProblemKind classifies the problems found by validation.
+type ProblemKind int
+
+const (
+	// PageCount is a mismatch between the pages begun and the count given to Init:
+	// too many or too few, a page numbered outside the count, or a page begun twice.
+	PageCount ProblemKind = iota
+	// Unbalanced is a page whose graphics state saves and restores (Push and Pop, or q and Q),
+	// or text objects (BT and ET), do not pair.
+	Unbalanced
+	// UnknownFont is a font that is not one of the aliases (sans, serif, mono, symbol, body, heading).
+	UnknownFont
+	// OffPage is drawing that falls outside the page.
+	OffPage
+)
+
+// Problem is a mistake found by validation.
+type Problem struct {
+	Kind    ProblemKind
+	Page    int // page on which it was found, 0 for the document as a whole
+	Message string
+}
+
+// String describes the problem, with its page
+func (pr Problem) String() string {
+	if pr.Page == 0 {
+		return pr.Message
+	}
+	return fmt.Sprintf("page %d: %s", pr.Page, pr.Message)
+}
+
+// SetValidation turns validation on or off. While it is on, the content of each page is checked
+// as it ends, and the pages of the document as it ends; the problems found are returned by Problems.
+// Validation does not change the output.
+func (p *PDFDoc) SetValidation(on bool) {
+	if !on {
+		if p.lint != nil && p.Writer == p.lint {
+			p.Writer = p.lint.w
+		}
+		p.lint = nil
+	} else if p.lint == nil {
+		p.lint = &linter{seen: make(map[int]bool), reported: make(map[Problem]bool)}
+	}
+}
+
+// Problems returns the problems found by validation, in the order found
+func (p *PDFDoc) Problems() []Problem {
+	if p.lint == nil {
+		return nil
+	}
+	return p.lint.problems
+}
+
+// linter collects the content of the current page, passing it on to w, and the problems found
+type linter struct {
+	w        io.Writer
+	content  bytes.Buffer
+	seen     map[int]bool
+	problems []Problem
+	reported map[Problem]bool
+}
+
+func (l *linter) Write(b []byte) (int, error) {
+	l.content.Write(b)
+	return l.w.Write(b)
+}
+
+// problem records a problem, unless it has already been recorded
+func (p *PDFDoc) problem(kind ProblemKind, page int, format string, args ...interface{}) {
+	if p.lint == nil {
+		return
+	}
+	pr := Problem{Kind: kind, Page: page, Message: fmt.Sprintf(format, args...)}
+	if !p.lint.reported[pr] {
+		p.lint.reported[pr] = true
+		p.lint.problems = append(p.lint.problems, pr)
+	}
+}
+
+// lintbegin checks the page number of a new page, and begins collecting its content
+func (p *PDFDoc) lintbegin(n int) {
+	l := p.lint
+	if l == nil {
+		return
+	}
+	if p.inpage {
+		p.problem(PageCount, p.page, "page %d begun before page %d ended", n, p.page)
+	}
+	if n < 1 || n > p.npages {
+		p.problem(PageCount, n, "page %d is outside the %d pages given to Init", n, p.npages)
+	}
+	if l.seen[n] {
+		p.problem(PageCount, n, "page %d begun more than once", n)
+	}
+	l.seen[n] = true
+	l.content.Reset()
+	l.w = p.Writer
+	p.Writer = l
+}
+
+// lintend stops collecting the content of the page, and checks it
+func (p *PDFDoc) lintend() {
+	l := p.lint
+	if l == nil || p.Writer != l {
+		return
+	}
+	p.Writer = l.w
+	p.lintcontent(l.content.Bytes())
+	l.content.Reset()
+}
+
+// lintdoc checks the pages of the document
+func (p *PDFDoc) lintdoc() {
+	if p.lint == nil {
+		return
+	}
+	if p.inpage {
+		p.problem(PageCount, p.page, "page %d not ended", p.page)
+		p.lintend()
+	}
+	if p.pagecount != p.npages {
+		p.problem(PageCount, 0, "%d pages begun, but Init was given %d", p.pagecount, p.npages)
+	}
+	for n := 1; n <= p.npages; n++ {
+		if !p.lint.seen[n] {
+			p.problem(PageCount, 0, "page %d never begun", n)
+		}
+	}
+}
+
+// offpagemargin is how far outside the page drawing may go before it is reported,
+// allowing for the rounding of coordinates
+const offpagemargin = 0.5
+
+// lintcontent checks a page's content stream: that q and Q, and BT and ET, pair,
+// and that the points of paths, text, and images fall on the page
+func (p *PDFDoc) lintcontent(content []byte) {
+	page := p.page
+	var stack []matrix // graphics states saved by q
+	ctm := identity
+	var text matrix // the text line matrix
+	depth, intext := 0, false
+	offpage, firstx, firsty := 0, 0.0, 0.0
+	check := func(x, y float64) {
+		m := ctm
+		if intext {
+			m = text.multiply(ctm)
+		}
+		px, py := m.apply(x, y)
+		on := px >= -offpagemargin && py >= -offpagemargin && px <= p.width+offpagemargin && py <= p.height+offpagemargin
+		if !on { // NaN coordinates are off the page too
+			if offpage == 0 {
+				firstx, firsty = px, py
+			}
+			offpage++
+		}
+	}
+	var operands []float64
+	sc := contentscanner{b: content}
+	for {
+		tok, number, ok := sc.next()
+		if !ok {
+			break
+		}
+		if number {
+			v, _ := strconv.ParseFloat(tok, 64)
+			operands = append(operands, v)
+			continue
+		}
+		args := operands
+		operands = operands[:0]
+		switch tok {
+		case "q":
+			stack = append(stack, ctm)
+			depth++
+		case "Q":
+			if depth == 0 {
+				p.problem(Unbalanced, page, "Q without a matching q")
+				continue
+			}
+			depth--
+			ctm = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		case "BT":
+			if intext {
+				p.problem(Unbalanced, page, "BT within a text object")
+			}
+			intext, text = true, identity
+		case "ET":
+			if !intext {
+				p.problem(Unbalanced, page, "ET without a matching BT")
+			}
+			intext = false
+		case "cm":
+			if len(args) == 6 {
+				ctm = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}.multiply(ctm)
+			}
+		case "Td", "TD":
+			if len(args) == 2 {
+				text = matrix{1, 0, 0, 1, args[0], args[1]}.multiply(text)
+				check(0, 0)
+			}
+		case "Tm":
+			if len(args) == 6 {
+				text = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+				check(0, 0)
+			}
+		case "m", "l", "c", "v", "y":
+			for i := 0; i+1 < len(args); i += 2 {
+				check(args[i], args[i+1])
+			}
+		case "re":
+			if len(args) == 4 {
+				x, y, w, h := args[0], args[1], args[2], args[3]
+				check(x, y)
+				check(x+w, y+h)
+			}
+		case "Do":
+			check(0, 0)
+			check(1, 1)
+		}
+	}
+	if depth > 0 {
+		p.problem(Unbalanced, page, "%d q without a matching Q", depth)
+	}
+	if intext {
+		p.problem(Unbalanced, page, "BT without a matching ET")
+	}
+	if offpage > 0 {
+		p.problem(OffPage, page, "drawing off the page: %d points, the first at (%.2f, %.2f)", offpage, firstx, firsty)
+	}
+}
+
+// contentscanner splits a content stream into numbers and operators,
+// skipping strings, names, arrays, and dictionaries
+type contentscanner struct {
+	b []byte
+	i int
+}
+
+// next returns the next number or operator, and whether it is a number
+func (sc *contentscanner) next() (string, bool, bool) {
+	for sc.i < len(sc.b) {
+		c := sc.b[sc.i]
+		switch {
+		case c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '[' || c == ']':
+			sc.i++
+		case c == '%':
+			for sc.i < len(sc.b) && sc.b[sc.i] != '\n' {
+				sc.i++
+			}
+		case c == '(':
+			sc.skipstring()
+		case c == '<' || c == '>' || c == '/' || c == '{' || c == '}':
+			// names, hex strings, and dictionaries are operands that do not matter here
+			sc.i++
+			for sc.i < len(sc.b) && !delimiter(sc.b[sc.i]) {
+				sc.i++
+			}
+		default:
+			start := sc.i
+			for sc.i < len(sc.b) && !delimiter(sc.b[sc.i]) {
+				sc.i++
+			}
+			if sc.i == start {
+				sc.i++
+				continue
+			}
+			tok := string(sc.b[start:sc.i])
+			_, err := strconv.ParseFloat(tok, 64)
+			return tok, err == nil, true
+		}
+	}
+	return "", false, false
+}
+
+// skipstring skips a literal string, with its nested parentheses and escapes
+func (sc *contentscanner) skipstring() {
+	depth := 0
+	for ; sc.i < len(sc.b); sc.i++ {
+		switch sc.b[sc.i] {
+		case '\\':
+			sc.i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				sc.i++
+				return
+			}
+		}
+	}
+}
+
+// delimiter reports whether c ends a token
+func delimiter(c byte) bool {
+	switch c {
+	case ' ', '\n', '\r', '\t', '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// apply returns the point (x,y) transformed by m
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+}